@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/Tidwell32/zack/apps/api/internal/config"
+	"github.com/Tidwell32/zack/apps/api/internal/database"
+	"github.com/Tidwell32/zack/apps/api/internal/disc"
+	"github.com/Tidwell32/zack/apps/api/internal/repository"
+	"github.com/Tidwell32/zack/apps/api/internal/services"
+	"github.com/Tidwell32/zack/apps/api/internal/storage"
+)
+
+// Checks user discs against the catalog: re-links discs whose catalog entry
+// is gone (by brand + name slug), reports discs that can't be matched, and
+// flags stock flight numbers that drifted from the catalog.
+//
+//	CONFIRM_DOCTOR=yes  apply changes (dry run otherwise)
+//	REFRESH_STOCK=true  also overwrite drifted stock flight numbers
+//	DOCTOR_USER_ID=...  only check one user's discs
+func main() {
+	log.Println("🩺 Checking user discs against the catalog...")
+
+	opts := disc.RelinkOptions{
+		DryRun:       os.Getenv("CONFIRM_DOCTOR") != "yes",
+		RefreshStock: os.Getenv("REFRESH_STOCK") == "true",
+		UserID:       os.Getenv("DOCTOR_USER_ID"),
+	}
+	if opts.DryRun {
+		log.Println("ℹ️  Dry run. Set CONFIRM_DOCTOR=yes to apply the changes.")
+	}
+
+	// Load config
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Connect to MongoDB
+	ctx := context.Background()
+	mongoDB, err := database.NewMongoDB(cfg.MongoURI, cfg.MongoDatabase)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	defer mongoDB.Close(ctx)
+
+	blobs, err := storage.New(cfg)
+	if err != nil {
+		log.Fatalf("Failed to set up blob storage: %v", err)
+	}
+
+	discRepo := repository.NewMongoDiscRepository(mongoDB.Collection("discs"))
+	catalogService := services.NewCatalogService(
+		repository.NewMongoDiscCatalogRepository(mongoDB.Collection("disc_catalog")),
+		repository.NewMongoCustomDiscRepository(mongoDB.Collection("custom_catalog_discs")),
+		repository.NewMongoUserSettingsRepository(mongoDB.Collection("user_settings")),
+		discRepo,
+		repository.NewMongoCatalogOverrideRepository(mongoDB.Collection("catalog_overrides")),
+		repository.NewMongoPlasticRepository(mongoDB.Collection("plastics")),
+		repository.NewMongoCatalogChangeRepository(mongoDB.Collection("catalog_changes")),
+	)
+	discService := services.NewDiscService(
+		discRepo,
+		repository.NewMongoBagRepository(mongoDB.Collection("bags")),
+		catalogService,
+		repository.NewMongoUDiscRepository(mongoDB.Collection("udisc_rounds")),
+		repository.NewMongoTechDiscRepository(mongoDB.Collection("techdisc_throws")),
+		blobs,
+	)
+
+	report, err := discService.RelinkCatalogDiscs(ctx, opts)
+	if err != nil {
+		log.Fatalf("Failed to check catalog links: %v", err)
+	}
+
+	for _, e := range report.Entries {
+		switch e.Outcome {
+		case disc.LinkRelinked:
+			log.Printf("🔗 %s %s (%s): %s -> %s", e.Brand, e.Name, e.DiscID.Hex(), e.OldCatalogDiscID, e.NewCatalogDiscID)
+		case disc.LinkUnresolved:
+			log.Printf("❓ %s %s (%s): no catalog match for %s", e.Brand, e.Name, e.DiscID.Hex(), e.OldCatalogDiscID)
+		}
+		if e.StockBefore != nil {
+			log.Printf("✈️  %s %s (%s): stock %g/%g/%g/%g, catalog %g/%g/%g/%g", e.Brand, e.Name, e.DiscID.Hex(),
+				e.StockBefore.Speed, e.StockBefore.Glide, e.StockBefore.Turn, e.StockBefore.Fade,
+				e.StockAfter.Speed, e.StockAfter.Glide, e.StockAfter.Turn, e.StockAfter.Fade)
+		}
+	}
+
+	log.Printf("📊 %d disc(s) checked: %d linked, %d re-linked, %d unresolved, %d with drifted stock numbers (%d refreshed)",
+		report.Checked, report.Linked, report.Relinked, report.Unresolved, report.StaleStock, report.StockRefreshed)
+	if opts.DryRun {
+		log.Println("ℹ️  Nothing was changed.")
+		return
+	}
+	log.Println("✅ Catalog doctor complete!")
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+
+	"github.com/Tidwell32/zack/apps/api/internal/seeding"
+)
+
+// Downloads the DiscIt catalog and writes it as a snapshot for
+// CATALOG_SOURCE=file or for the embedded fallback.
+//
+//	go run ./cmd/catalog-snapshot [output path]
+func main() {
+	out := "internal/seeding/snapshot/discit.json"
+	if len(os.Args) > 1 {
+		out = os.Args[1]
+	}
+
+	url := os.Getenv("CATALOG_SOURCE_URL")
+	source := seeding.NewHTTPCatalogSource(url)
+
+	log.Printf("📥 Fetching disc catalog from %s...", source.Name())
+	discs, err := source.Fetch(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to fetch catalog: %v", err)
+	}
+
+	data, err := json.MarshalIndent(discs, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode snapshot: %v", err)
+	}
+
+	if err := os.WriteFile(out, append(data, '\n'), 0o644); err != nil {
+		log.Fatalf("Failed to write snapshot: %v", err)
+	}
+
+	log.Printf("✅ Wrote %d discs to %s", len(discs), out)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/Tidwell32/zack/apps/api/internal/config"
+	"github.com/Tidwell32/zack/apps/api/internal/database"
+	"github.com/Tidwell32/zack/apps/api/internal/repository"
+	"github.com/Tidwell32/zack/apps/api/internal/services"
+)
+
+// One-off cleanup for discs whose bag was deleted before bag deletes
+// cascaded. Those discs are moved to the backup shelf.
+func main() {
+	log.Println("🔍 Looking for discs in deleted bags...")
+
+	// Dry run unless explicitly confirmed
+	dryRun := os.Getenv("CONFIRM_REPAIR") != "yes"
+	if dryRun {
+		log.Println("ℹ️  Dry run. Set CONFIRM_REPAIR=yes to apply the changes.")
+	}
+
+	// Load config
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Connect to MongoDB
+	ctx := context.Background()
+	mongoDB, err := database.NewMongoDB(cfg.MongoURI, cfg.MongoDatabase)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	defer mongoDB.Close(ctx)
+
+	if err := mongoDB.RequireReplicaSet(ctx); err != nil {
+		log.Fatalf("Failed to start: %v", err)
+	}
+
+	discRepo := repository.NewMongoDiscRepository(mongoDB.Collection("discs"))
+	bagService := services.NewBagService(
+		repository.NewMongoBagRepository(mongoDB.Collection("bags")),
+		discRepo,
+		services.NewCatalogService(
+			repository.NewMongoDiscCatalogRepository(mongoDB.Collection("disc_catalog")),
+			repository.NewMongoCustomDiscRepository(mongoDB.Collection("custom_catalog_discs")),
+			repository.NewMongoUserSettingsRepository(mongoDB.Collection("user_settings")),
+			discRepo,
+			repository.NewMongoCatalogOverrideRepository(mongoDB.Collection("catalog_overrides")),
+			repository.NewMongoPlasticRepository(mongoDB.Collection("plastics")),
+			repository.NewMongoCatalogChangeRepository(mongoDB.Collection("catalog_changes")),
+		),
+		repository.NewMongoBagTemplateRepository(mongoDB.Collection("bag_templates")),
+		repository.NewMongoTxRunner(mongoDB.Client),
+		// Repairs never delete discs, so there are no photos to remove.
+		nil,
+	)
+
+	report, err := bagService.RepairOrphans(ctx, dryRun)
+	if err != nil {
+		log.Fatalf("Failed to repair orphaned discs: %v", err)
+	}
+
+	if len(report.Bags) == 0 {
+		log.Println("✅ No orphaned discs found.")
+		return
+	}
+
+	var shelved, detached int64
+	for _, b := range report.Bags {
+		log.Printf("🎒 Bag %s: %d disc(s) to shelf, %d other disc(s) detached", b.BagID.Hex(), b.DiscsShelved, b.DiscsDetached)
+		shelved += b.DiscsShelved
+		detached += b.DiscsDetached
+	}
+
+	log.Printf("📊 %d deleted bag(s), %d disc(s) shelved, %d disc(s) detached", len(report.Bags), shelved, detached)
+	if dryRun {
+		log.Println("ℹ️  Nothing was changed.")
+		return
+	}
+	log.Println("✅ Orphan repair complete!")
+}
@@ -6,19 +6,21 @@ import (
 	"log"
 	"os"
 
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
 	"github.com/Tidwell32/zack/apps/api/internal/config"
 	"github.com/Tidwell32/zack/apps/api/internal/database"
 	"github.com/Tidwell32/zack/apps/api/internal/seeding"
+	"github.com/Tidwell32/zack/apps/api/internal/services"
 )
 
 func main() {
 	log.Println("🔄 Starting disc catalog re-seed...")
-	log.Println("⚠️  WARNING: This will delete all existing catalog data!")
+	log.Println("⚠️  WARNING: This will overwrite catalog entries with fresh source data!")
 
 	// Require explicit confirmation
 	confirm := os.Getenv("CONFIRM_RESEED")
 	if confirm != "yes" {
-		log.Fatal("❌ Aborted. Set CONFIRM_RESEED=yes to confirm this destructive operation.")
+		log.Fatal("❌ Aborted. Set CONFIRM_RESEED=yes to confirm this operation.")
 	}
 
 	log.Println("✅ Confirmation received, proceeding with re-seed...")
@@ -53,14 +55,25 @@ func main() {
 		log.Printf("📊 Current catalog contains %d discs", count)
 	}
 
-	// Force re-seed (deletes existing data and fetches fresh)
-	log.Println("🗑️  Deleting existing catalog...")
-	log.Println("📥 Fetching fresh data from DiscIt API...")
+	source, err := seeding.NewCatalogSource(cfg)
+	if err != nil {
+		log.Fatalf("Failed to set up catalog source: %v", err)
+	}
 
-	if err := seeding.SeedDiscCatalog(ctx, mongoDB.Database, true); err != nil {
+	// Sync in place so existing catalog IDs (and the discs pointing at them) survive
+	result, err := seeding.SyncDiscCatalog(ctx, mongoDB.Database, source)
+	if err != nil {
 		log.Fatalf("Failed to re-seed disc catalog: %v", err)
 	}
 
+	for _, c := range result.Changes {
+		// Flight-number updates are already logged by the sync
+		if c.Kind == catalog.ChangeUpdated {
+			continue
+		}
+		log.Printf("📝 %-12s %s %s", c.Kind, c.Brand, c.Name)
+	}
+
 	// Show new catalog stats
 	newCount, err := collection.CountDocuments(ctx, map[string]interface{}{})
 	if err == nil {
@@ -70,4 +83,5 @@ func main() {
 	}
 
 	log.Println("✅ Disc catalog re-seed complete!")
+	log.Printf("ℹ️  Running APIs rebuild their catalog search index every %s; restart them to pick up these changes sooner.", services.CatalogIndexTTL)
 }
@@ -26,10 +26,19 @@ func main() {
 	}
 	defer mongoDB.Close(ctx)
 
+	source, err := seeding.NewCatalogSource(cfg)
+	if err != nil {
+		log.Fatalf("Failed to set up catalog source: %v", err)
+	}
+
 	// Seed disc catalog (never force reseed in manual seed command)
-	if err := seeding.SeedDiscCatalog(ctx, mongoDB.Database, false); err != nil {
+	if err := seeding.SeedDiscCatalog(ctx, mongoDB.Database, source, false); err != nil {
 		log.Fatalf("Failed to seed disc catalog: %v", err)
 	}
 
+	if _, err := seeding.SeedPlastics(ctx, mongoDB.Database, cfg.PlasticsFile); err != nil {
+		log.Fatalf("Failed to seed plastics: %v", err)
+	}
+
 	log.Println("✅ Database seeding complete!")
 }
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"net/http"
 
 	"github.com/Tidwell32/zack/apps/api/internal/config"
@@ -9,19 +11,34 @@ import (
 	"github.com/Tidwell32/zack/apps/api/internal/middleware"
 	"github.com/Tidwell32/zack/apps/api/internal/repository"
 	"github.com/Tidwell32/zack/apps/api/internal/services"
+	"github.com/Tidwell32/zack/apps/api/internal/storage"
 )
 
 type App struct {
 	Config *config.Config
 	DB     *database.MongoDB
+	Blobs  storage.BlobStore
+
+	catalogService *services.CatalogService
 }
 
-func New(cfg *config.Config, db *database.MongoDB) *App {
+func New(cfg *config.Config, db *database.MongoDB, blobs storage.BlobStore) *App {
 	return &App{
 		Config: cfg,
 		DB:     db,
+		Blobs:  blobs,
 	}
 }
+
+// LoadCatalogIndex builds the fuzzy catalog index. Call it after Routes,
+// once the catalog has been seeded.
+func (a *App) LoadCatalogIndex(ctx context.Context) error {
+	if a.catalogService == nil {
+		return errors.New("catalog index needs Routes to be built first")
+	}
+	return a.catalogService.RefreshIndex(ctx)
+}
+
 func (a *App) Routes() http.Handler {
 	mux := http.NewServeMux()
 
@@ -29,14 +46,28 @@ func (a *App) Routes() http.Handler {
 
 	catalogCollection := a.DB.Collection("disc_catalog")
 	catalogRepo := repository.NewMongoDiscCatalogRepository(catalogCollection)
-
+	customDiscCollection := a.DB.Collection("custom_catalog_discs")
+	customDiscRepo := repository.NewMongoCustomDiscRepository(customDiscCollection)
 	discCollection := a.DB.Collection("discs")
 	discRepo := repository.NewMongoDiscRepository(discCollection)
-	discService := services.NewDiscService(discRepo, catalogRepo)
+	settingsCollection := a.DB.Collection("user_settings")
+	settingsRepo := repository.NewMongoUserSettingsRepository(settingsCollection)
+	settingsService := services.NewSettingsService(settingsRepo)
+	overrideCollection := a.DB.Collection("catalog_overrides")
+	overrideRepo := repository.NewMongoCatalogOverrideRepository(overrideCollection)
+	plasticCollection := a.DB.Collection("plastics")
+	plasticRepo := repository.NewMongoPlasticRepository(plasticCollection)
+	changeCollection := a.DB.Collection("catalog_changes")
+	changeRepo := repository.NewMongoCatalogChangeRepository(changeCollection)
+	catalogService := services.NewCatalogService(catalogRepo, customDiscRepo, settingsRepo, discRepo, overrideRepo, plasticRepo, changeRepo)
+	a.catalogService = catalogService
 
 	bagCollection := a.DB.Collection("bags")
 	bagRepo := repository.NewMongoBagRepository(bagCollection)
-	bagService := services.NewBagService(bagRepo, discRepo)
+	bagTemplateCollection := a.DB.Collection("bag_templates")
+	bagTemplateRepo := repository.NewMongoBagTemplateRepository(bagTemplateCollection)
+	txRunner := repository.NewMongoTxRunner(a.DB.Client)
+	bagService := services.NewBagService(bagRepo, discRepo, catalogService, bagTemplateRepo, txRunner, a.Blobs)
 
 	techDiscCollection := a.DB.Collection("techdisc_throws")
 	techDiscRepo := repository.NewMongoTechDiscRepository(techDiscCollection)
@@ -44,15 +75,29 @@ func (a *App) Routes() http.Handler {
 
 	udiscRoundsCollection := a.DB.Collection("udisc_rounds")
 	udiscRepo := repository.NewMongoUDiscRepository(udiscRoundsCollection)
-	udiscService := services.NewUDiscService(udiscRepo)
+	udiscAliasCollection := a.DB.Collection("udisc_player_aliases")
+	udiscAliasRepo := repository.NewMongoUDiscPlayerAliasRepository(udiscAliasCollection)
+	udiscAchievementCollection := a.DB.Collection("udisc_player_achievements")
+	udiscAchievementRepo := repository.NewMongoUDiscAchievementRepository(udiscAchievementCollection)
+	udiscService := services.NewUDiscService(udiscRepo, udiscAliasRepo, udiscAchievementRepo)
+
+	discService := services.NewDiscService(discRepo, bagRepo, catalogService, udiscRepo, techDiscRepo, a.Blobs)
+
+	leagueCollection := a.DB.Collection("leagues")
+	leagueSeasonCollection := a.DB.Collection("league_seasons")
+	leagueEventCollection := a.DB.Collection("league_events")
+	leagueRepo := repository.NewMongoLeagueRepository(leagueCollection, leagueSeasonCollection, leagueEventCollection)
+	leagueService := services.NewLeagueService(leagueRepo, udiscRepo, udiscAliasRepo)
 
 	health := handlers.NewHealthHandler()
 	auth := handlers.NewAuthHandler(a.Config, authService)
 	bags := handlers.NewBagHandler(a.Config, bagService)
 	discs := handlers.NewDiscHandler(a.Config, discService)
-	catalog := handlers.NewCatalogHandler(catalogRepo)
+	catalog := handlers.NewCatalogHandler(a.Config, catalogService)
 	techDisc := handlers.NewTechDiscHandler(a.Config, techDiscService)
 	udisc := handlers.NewUDiscHandler(a.Config, udiscService)
+	leagues := handlers.NewLeagueHandler(a.Config, leagueService)
+	userSettings := handlers.NewSettingsHandler(a.Config, settingsService)
 
 	mux.HandleFunc("GET /health", health.Handle)
 
@@ -60,19 +105,53 @@ func (a *App) Routes() http.Handler {
 	mux.HandleFunc("POST /auth/logout", auth.Logout)
 	mux.HandleFunc("GET /auth/whoami", auth.WhoAmI)
 
+	mux.HandleFunc("GET /settings", userSettings.GetSettings)
+	mux.HandleFunc("PUT /settings/suggest", userSettings.UpdateSuggestPreferences)
+
 	mux.HandleFunc("POST /bags", bags.CreateBag)
 	mux.HandleFunc("GET /bags", bags.GetBagsForCurrentUser)
+	mux.HandleFunc("POST /bags/import", bags.ImportBag)
 	mux.HandleFunc("GET /bags/{id}", bags.GetBag)
 	mux.HandleFunc("PATCH /bags/{id}", bags.UpdateBag)
 	mux.HandleFunc("DELETE /bags/{id}", bags.DeleteBag)
+	mux.HandleFunc("GET /bags/{id}/gaps", bags.GetBagGaps)
+	mux.HandleFunc("GET /bags/{id}/export", bags.ExportBag)
+	mux.HandleFunc("POST /bags/{id}/clone", bags.CloneBag)
+	mux.HandleFunc("POST /bags/{id}/template", bags.SaveTemplate)
+	mux.HandleFunc("GET /bag-templates", bags.GetTemplates)
+	mux.HandleFunc("DELETE /bag-templates/{id}", bags.DeleteTemplate)
+	mux.HandleFunc("POST /bag-templates/{id}/instantiate", bags.InstantiateTemplate)
+	mux.HandleFunc("POST /bags/{id}/shares", bags.CreateShareToken)
+	mux.HandleFunc("GET /bags/{id}/shares", bags.GetShareTokens)
+	mux.HandleFunc("DELETE /bags/{id}/shares/{token}", bags.RevokeShareToken)
+	mux.HandleFunc("GET /share/bags/{token}", bags.GetSharedBag)
 
 	mux.HandleFunc("POST /bags/{id}/discs", discs.AddDiscToBag)
 	mux.HandleFunc("GET /bags/{id}/discs", discs.GetDiscsForBag)
 	mux.HandleFunc("PATCH /discs/{id}", discs.UpdateDisc)
 	mux.HandleFunc("DELETE /discs/{id}", discs.DeleteDisc)
-
+	mux.HandleFunc("PUT /discs/{id}/status", discs.UpdateDiscStatus)
+	mux.HandleFunc("GET /discs/shelf", discs.GetShelf)
+	mux.HandleFunc("GET /discs/{id}/history", discs.GetDiscHistory)
+	mux.HandleFunc("POST /discs/{id}/photos", discs.UploadPhoto)
+	mux.HandleFunc("GET /discs/{id}/photos/{photoId}", discs.GetPhoto)
+	mux.HandleFunc("DELETE /discs/{id}/photos/{photoId}", discs.DeletePhoto)
+
+	mux.HandleFunc("GET /catalog/discs", catalog.FacetedSearch)
 	mux.HandleFunc("GET /catalog/discs/search", catalog.SearchDiscs)
 	mux.HandleFunc("GET /catalog/discs/suggest", catalog.SuggestDiscs)
+	mux.HandleFunc("GET /catalog/discs/{id}/similar", catalog.SimilarDiscs)
+	mux.HandleFunc("PATCH /catalog/discs/{id}", catalog.OverrideCatalogDisc)
+	mux.HandleFunc("GET /catalog/discs/{id}/override", catalog.GetCatalogOverride)
+	mux.HandleFunc("DELETE /catalog/discs/{id}/override", catalog.ClearCatalogOverride)
+	mux.HandleFunc("GET /catalog/discs/{id}/plastics", catalog.GetPlasticOptions)
+	mux.HandleFunc("GET /catalog/plastics", catalog.SearchPlastics)
+	mux.HandleFunc("GET /catalog/changes", catalog.GetCatalogChanges)
+	mux.HandleFunc("GET /catalog/new", catalog.GetNewReleases)
+	mux.HandleFunc("GET /catalog/custom-discs", catalog.GetCustomDiscs)
+	mux.HandleFunc("POST /catalog/custom-discs", catalog.CreateCustomDisc)
+	mux.HandleFunc("PUT /catalog/custom-discs/{id}", catalog.UpdateCustomDisc)
+	mux.HandleFunc("DELETE /catalog/custom-discs/{id}", catalog.DeleteCustomDisc)
 
 	mux.HandleFunc("POST /techdisc/import", techDisc.ImportTechDiscCSV)
 	mux.HandleFunc("GET /techdisc/throws", techDisc.GetThrows)
@@ -80,8 +159,33 @@ func (a *App) Routes() http.Handler {
 
 	mux.HandleFunc("POST /udisc/import", udisc.ImportUDiscCSV)
 	mux.HandleFunc("GET /udisc/rounds", udisc.GetRounds)
+	mux.HandleFunc("POST /udisc/rounds", udisc.CreateRound)
+	mux.HandleFunc("GET /udisc/rounds/{id}", udisc.GetRound)
+	mux.HandleFunc("PATCH /udisc/rounds/{id}", udisc.UpdateRound)
+	mux.HandleFunc("DELETE /udisc/rounds/{id}", udisc.DeleteRound)
+	mux.HandleFunc("PATCH /udisc/rounds/{id}/players/{name}", udisc.CorrectPlayer)
+	mux.HandleFunc("POST /udisc/rounds/{id}/players", udisc.AddPlayerToRound)
+	mux.HandleFunc("PATCH /udisc/rounds/{id}/scores", udisc.RecordScore)
+	mux.HandleFunc("POST /udisc/rounds/{id}/finalize", udisc.FinalizeRound)
 	mux.HandleFunc("GET /udisc/players", udisc.GetPlayers)
+	mux.HandleFunc("GET /udisc/players/aliases", udisc.GetPlayerAliases)
+	mux.HandleFunc("POST /udisc/players/aliases", udisc.MergePlayers)
+	mux.HandleFunc("DELETE /udisc/players/aliases/{alias}", udisc.DeletePlayerAlias)
+	mux.HandleFunc("GET /udisc/players/{name}/achievements", udisc.GetPlayerAchievements)
 	mux.HandleFunc("GET /udisc/courses", udisc.GetCourses)
+	mux.HandleFunc("GET /udisc/predict", udisc.PredictScore)
+
+	mux.HandleFunc("POST /leagues", leagues.CreateLeague)
+	mux.HandleFunc("GET /leagues", leagues.GetLeagues)
+	mux.HandleFunc("GET /leagues/{id}", leagues.GetLeague)
+	mux.HandleFunc("PATCH /leagues/{id}", leagues.UpdateLeague)
+	mux.HandleFunc("POST /leagues/{id}/seasons", leagues.CreateSeason)
+	mux.HandleFunc("GET /leagues/{id}/seasons", leagues.GetSeasons)
+	mux.HandleFunc("POST /league-seasons/{id}/events", leagues.CreateEvent)
+	mux.HandleFunc("GET /league-seasons/{id}/events", leagues.GetEvents)
+	mux.HandleFunc("GET /league-seasons/{id}/standings", leagues.GetStandings)
+	mux.HandleFunc("GET /league-events/{id}", leagues.GetEvent)
+	mux.HandleFunc("PUT /league-events/{id}/round", leagues.ScoreEvent)
 
 	handler := middleware.AuthMiddleware(a.Config, authService, mux)
 	handler = middleware.CORSMiddleware(a.Config, handler)
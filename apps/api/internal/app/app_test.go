@@ -0,0 +1,795 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Tidwell32/zack/apps/api/internal/bag"
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+	"github.com/Tidwell32/zack/apps/api/internal/config"
+	"github.com/Tidwell32/zack/apps/api/internal/database"
+	"github.com/Tidwell32/zack/apps/api/internal/migrations"
+	"github.com/Tidwell32/zack/apps/api/internal/services"
+	"github.com/Tidwell32/zack/apps/api/internal/storage"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// The route tests need a MongoDB replica set (bag deletes and clones run in
+// transactions). They are skipped unless MONGO_TEST_URI points at one, e.g.
+//
+//	MONGO_TEST_URI='mongodb://localhost:27017/?directConnection=true' go test ./internal/app/
+//
+// Each run works in its own database and drops it afterwards.
+
+const (
+	testOwner    = "owner-1"
+	testViewer   = "viewer-1"
+	testStranger = "stranger-1"
+
+	roleOwner    = "owner"
+	roleViewer   = "viewer"
+	roleStranger = "stranger"
+	roleAnon     = "anonymous"
+)
+
+var roles = []string{roleOwner, roleViewer, roleStranger, roleAnon}
+
+var roleUsers = map[string]string{
+	roleOwner:    testOwner,
+	roleViewer:   testViewer,
+	roleStranger: testStranger,
+}
+
+// The viewer role is only on the viewer list of the shared bag; everywhere
+// else it is just another logged-in user.
+type visibility struct {
+	name       string
+	visibility string
+	viewerIDs  []string
+}
+
+var visibilities = []visibility{
+	{name: "public", visibility: bag.VisibilityPublic},
+	{name: "private", visibility: bag.VisibilityPrivate},
+	{name: "shared", visibility: bag.VisibilityPrivate, viewerIDs: []string{testViewer}},
+}
+
+func canView(vis visibility, role string) bool {
+	switch {
+	case role == roleOwner, vis.visibility == bag.VisibilityPublic:
+		return true
+	case role == roleViewer:
+		return len(vis.viewerIDs) > 0
+	default:
+		return false
+	}
+}
+
+// allowed accepts any status that isn't an auth failure or a server error,
+// so validation outcomes of non-bag routes don't make the tests brittle.
+const allowed = 0
+
+// Fixed so the catalog index built at startup keeps matching after resets.
+var testCatalogID = bson.ObjectID{0x65, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01}
+
+type upload struct {
+	field    string
+	filename string
+	data     []byte
+	values   map[string]string
+}
+
+// routeCase is one request against a registered route. Targets and bodies
+// use :name placeholders that are filled in from the seeded fixture.
+type routeCase struct {
+	route  string
+	target string
+	body   string
+	upload func() *upload
+}
+
+type bagRouteCase struct {
+	routeCase
+	want func(vis visibility, role string) int
+}
+
+type userRouteCase struct {
+	routeCase
+	// Indexed like roles: owner, viewer, stranger, anonymous.
+	want [4]int
+}
+
+func bagRead(vis visibility, role string) int {
+	if canView(vis, role) {
+		return http.StatusOK
+	}
+	return http.StatusNotFound
+}
+
+// bagWrite needs ownership of the bag. Anonymous callers either get 401 or,
+// on the demo routes, a response that isn't saved.
+func bagWrite(anon int) func(visibility, string) int {
+	return func(vis visibility, role string) int {
+		switch {
+		case role == roleOwner:
+			return http.StatusOK
+		case role == roleAnon:
+			return anon
+		case canView(vis, role):
+			return http.StatusForbidden
+		default:
+			return http.StatusNotFound
+		}
+	}
+}
+
+func bagClone(vis visibility, role string) int {
+	if role == roleAnon {
+		return http.StatusUnauthorized
+	}
+	return bagRead(vis, role)
+}
+
+// discWrite needs ownership of the disc, whoever can see its bag.
+func discWrite(anon int) func(visibility, string) int {
+	return func(_ visibility, role string) int {
+		switch role {
+		case roleOwner:
+			return http.StatusOK
+		case roleAnon:
+			return anon
+		default:
+			return http.StatusForbidden
+		}
+	}
+}
+
+func anyone(visibility, string) int {
+	return http.StatusOK
+}
+
+var bagRoutes = []bagRouteCase{
+	{routeCase{route: "GET /bags/{id}", target: "/bags/:bag"}, bagRead},
+	{routeCase{route: "PATCH /bags/{id}", target: "/bags/:bag", body: `{"name":"Renamed Bag"}`}, bagWrite(http.StatusOK)},
+	{routeCase{route: "DELETE /bags/{id}", target: "/bags/:bag"}, bagWrite(http.StatusOK)},
+	{routeCase{route: "GET /bags/{id}/gaps", target: "/bags/:bag/gaps"}, bagRead},
+	{routeCase{route: "GET /bags/{id}/export", target: "/bags/:bag/export"}, bagRead},
+	{routeCase{route: "POST /bags/{id}/clone", target: "/bags/:bag/clone"}, bagClone},
+	{routeCase{route: "POST /bags/{id}/template", target: "/bags/:bag/template", body: `{"name":"Saved Template"}`}, bagWrite(http.StatusUnauthorized)},
+	{routeCase{route: "POST /bags/{id}/shares", target: "/bags/:bag/shares", body: `{}`}, bagWrite(http.StatusUnauthorized)},
+	{routeCase{route: "GET /bags/{id}/shares", target: "/bags/:bag/shares"}, bagWrite(http.StatusUnauthorized)},
+	{routeCase{route: "DELETE /bags/{id}/shares/{token}", target: "/bags/:bag/shares/:share"}, bagWrite(http.StatusUnauthorized)},
+	{routeCase{route: "GET /share/bags/{token}", target: "/share/bags/:share"}, anyone},
+
+	{routeCase{route: "POST /bags/{id}/discs", target: "/bags/:bag/discs", body: `{"catalogDiscId":":catalog"}`}, bagWrite(http.StatusOK)},
+	{routeCase{route: "GET /bags/{id}/discs", target: "/bags/:bag/discs"}, bagRead},
+	{routeCase{route: "PATCH /discs/{id}", target: "/discs/:disc", body: `{"notes":"updated"}`}, discWrite(http.StatusOK)},
+	{routeCase{route: "DELETE /discs/{id}", target: "/discs/:disc"}, discWrite(http.StatusOK)},
+	{routeCase{route: "PUT /discs/{id}/status", target: "/discs/:disc/status", body: `{"status":"backup"}`}, discWrite(http.StatusUnauthorized)},
+	{routeCase{route: "GET /discs/{id}/history", target: "/discs/:disc/history"}, bagRead},
+	{routeCase{route: "POST /discs/{id}/photos", target: "/discs/:disc/photos", upload: photoUpload}, discWrite(http.StatusUnauthorized)},
+	{routeCase{route: "GET /discs/{id}/photos/{photoId}", target: "/discs/:disc/photos/:photo"}, bagRead},
+	{routeCase{route: "DELETE /discs/{id}/photos/{photoId}", target: "/discs/:disc/photos/:photo"}, discWrite(http.StatusUnauthorized)},
+}
+
+// Rows for userRoutes. Data routes look records up under the caller's own
+// ID, so another user's records are simply not found; anonymous reads fall
+// back to the owner's data.
+var (
+	everyone   = [4]int{allowed, allowed, allowed, allowed}
+	loggedIn   = [4]int{allowed, allowed, allowed, http.StatusUnauthorized}
+	ownerOnly  = [4]int{allowed, http.StatusForbidden, http.StatusForbidden, http.StatusUnauthorized}
+	ownData    = [4]int{allowed, http.StatusNotFound, http.StatusNotFound, http.StatusUnauthorized}
+	ownDataGet = [4]int{allowed, http.StatusNotFound, http.StatusNotFound, allowed}
+)
+
+const customDiscBody = `{"name":"%s","brand":"Innova","category":"Putter","speed":2,"glide":3,"turn":0,"fade":1}`
+
+var userRoutes = []userRouteCase{
+	{routeCase{route: "GET /health", target: "/health"}, everyone},
+	{routeCase{route: "POST /auth/login", target: "/auth/login", body: `{"code":"000000"}`}, everyone},
+	{routeCase{route: "POST /auth/logout", target: "/auth/logout"}, everyone},
+	{routeCase{route: "GET /auth/whoami", target: "/auth/whoami"}, everyone},
+
+	{routeCase{route: "GET /settings", target: "/settings"}, loggedIn},
+	{routeCase{route: "PUT /settings/suggest", target: "/settings/suggest", body: `{"preferredBrands":["innova"],"excludedBrands":[],"ownedMoldPenalty":0}`}, loggedIn},
+
+	{routeCase{route: "POST /bags", target: "/bags", body: `{"name":"New Bag"}`}, everyone},
+	{routeCase{route: "GET /bags", target: "/bags"}, everyone},
+	{routeCase{route: "POST /bags/import", target: "/bags/import", upload: bagImportUpload}, loggedIn},
+	{routeCase{route: "GET /bag-templates", target: "/bag-templates"}, loggedIn},
+	{routeCase{route: "DELETE /bag-templates/{id}", target: "/bag-templates/:template"}, ownData},
+	{routeCase{route: "POST /bag-templates/{id}/instantiate", target: "/bag-templates/:template/instantiate"}, ownData},
+	{routeCase{route: "GET /discs/shelf", target: "/discs/shelf"}, everyone},
+
+	{routeCase{route: "GET /catalog/discs", target: "/catalog/discs"}, everyone},
+	{routeCase{route: "GET /catalog/discs/search", target: "/catalog/discs/search?q=destroyer"}, everyone},
+	{routeCase{route: "GET /catalog/discs/suggest", target: "/catalog/discs/suggest?minSpeed=1&maxSpeed=14&minStability=-5&maxStability=5"}, everyone},
+	{routeCase{route: "GET /catalog/discs/{id}/similar", target: "/catalog/discs/:catalog/similar"}, everyone},
+	{routeCase{route: "PATCH /catalog/discs/{id}", target: "/catalog/discs/:catalog", body: `{"speed":11}`}, ownerOnly},
+	{routeCase{route: "GET /catalog/discs/{id}/override", target: "/catalog/discs/:catalog/override"}, ownerOnly},
+	{routeCase{route: "DELETE /catalog/discs/{id}/override", target: "/catalog/discs/:catalog/override?field=speed"}, ownerOnly},
+	{routeCase{route: "GET /catalog/discs/{id}/plastics", target: "/catalog/discs/:catalog/plastics"}, everyone},
+	{routeCase{route: "GET /catalog/plastics", target: "/catalog/plastics?q=star"}, everyone},
+	{routeCase{route: "GET /catalog/changes", target: "/catalog/changes"}, everyone},
+	{routeCase{route: "GET /catalog/new", target: "/catalog/new"}, everyone},
+	{routeCase{route: "GET /catalog/custom-discs", target: "/catalog/custom-discs"}, everyone},
+	{routeCase{route: "POST /catalog/custom-discs", target: "/catalog/custom-discs", body: fmt.Sprintf(customDiscBody, "Second Proto")}, loggedIn},
+	{routeCase{route: "PUT /catalog/custom-discs/{id}", target: "/catalog/custom-discs/:custom", body: fmt.Sprintf(customDiscBody, "Renamed Proto")}, ownData},
+	{routeCase{route: "DELETE /catalog/custom-discs/{id}", target: "/catalog/custom-discs/:custom"}, ownData},
+
+	{routeCase{route: "POST /techdisc/import", target: "/techdisc/import", upload: techDiscUpload}, everyone},
+	{routeCase{route: "GET /techdisc/throws", target: "/techdisc/throws"}, everyone},
+	{routeCase{route: "GET /techdisc/sessions", target: "/techdisc/sessions"}, everyone},
+
+	{routeCase{route: "POST /udisc/import", target: "/udisc/import", upload: udiscUpload}, everyone},
+	{routeCase{route: "GET /udisc/rounds", target: "/udisc/rounds"}, everyone},
+	{routeCase{route: "POST /udisc/rounds", target: "/udisc/rounds", body: `{"courseName":"Other Park","layoutName":"Main","pars":[3,3],"players":["Owner"]}`}, loggedIn},
+	{routeCase{route: "GET /udisc/rounds/{id}", target: "/udisc/rounds/:round"}, ownDataGet},
+	{routeCase{route: "PATCH /udisc/rounds/{id}", target: "/udisc/rounds/:round", body: `{"pars":[3,4]}`}, ownData},
+	{routeCase{route: "DELETE /udisc/rounds/{id}", target: "/udisc/rounds/:round"}, ownData},
+	{routeCase{route: "PATCH /udisc/rounds/{id}/players/{name}", target: "/udisc/rounds/:round/players/Owner", body: `{"scores":[3,4]}`}, ownData},
+	{routeCase{route: "POST /udisc/rounds/{id}/players", target: "/udisc/rounds/:liveround/players", body: `{"playerName":"Guest"}`}, ownData},
+	{routeCase{route: "PATCH /udisc/rounds/{id}/scores", target: "/udisc/rounds/:liveround/scores", body: `{"playerName":"Owner","hole":1,"score":3}`}, ownData},
+	{routeCase{route: "POST /udisc/rounds/{id}/finalize", target: "/udisc/rounds/:liveround/finalize"}, ownData},
+	{routeCase{route: "GET /udisc/players", target: "/udisc/players"}, everyone},
+	{routeCase{route: "GET /udisc/players/aliases", target: "/udisc/players/aliases"}, everyone},
+	{routeCase{route: "POST /udisc/players/aliases", target: "/udisc/players/aliases", body: `{"alias":"O. Wner","canonicalName":"Owner"}`}, loggedIn},
+	{routeCase{route: "DELETE /udisc/players/aliases/{alias}", target: "/udisc/players/aliases/Ownr"}, ownData},
+	{routeCase{route: "GET /udisc/players/{name}/achievements", target: "/udisc/players/Owner/achievements"}, ownDataGet},
+	{routeCase{route: "GET /udisc/courses", target: "/udisc/courses"}, everyone},
+	{routeCase{route: "GET /udisc/predict", target: "/udisc/predict?layout=Main&player=Owner"}, ownDataGet},
+
+	{routeCase{route: "POST /leagues", target: "/leagues", body: `{"name":"New League"}`}, loggedIn},
+	{routeCase{route: "GET /leagues", target: "/leagues"}, everyone},
+	{routeCase{route: "GET /leagues/{id}", target: "/leagues/:league"}, ownDataGet},
+	{routeCase{route: "PATCH /leagues/{id}", target: "/leagues/:league", body: `{"name":"Renamed League"}`}, ownData},
+	{routeCase{route: "POST /leagues/{id}/seasons", target: "/leagues/:league/seasons", body: `{"name":"Fall","startDate":"2026-09-01","endDate":"2026-11-30"}`}, ownData},
+	{routeCase{route: "GET /leagues/{id}/seasons", target: "/leagues/:league/seasons"}, ownDataGet},
+	{routeCase{route: "POST /league-seasons/{id}/events", target: "/league-seasons/:season/events", body: `{"name":"Week 2","date":"2026-06-08"}`}, ownData},
+	{routeCase{route: "GET /league-seasons/{id}/events", target: "/league-seasons/:season/events"}, ownDataGet},
+	{routeCase{route: "GET /league-seasons/{id}/standings", target: "/league-seasons/:season/standings"}, ownDataGet},
+	{routeCase{route: "GET /league-events/{id}", target: "/league-events/:event"}, ownDataGet},
+	{routeCase{route: "PUT /league-events/{id}/round", target: "/league-events/:event/round", body: `{"roundId":":round"}`}, ownData},
+}
+
+// Catches routes added to Routes() without a row in the tables above. It
+// reads app.go so it runs without a database.
+func TestRouteTablesCoverEveryRoute(t *testing.T) {
+	src, err := os.ReadFile("app.go")
+	if err != nil {
+		t.Fatalf("failed to read app.go: %v", err)
+	}
+
+	covered := map[string]bool{moveDiscRoute: true}
+	for _, c := range bagRoutes {
+		covered[c.route] = true
+	}
+	for _, c := range userRoutes {
+		covered[c.route] = true
+	}
+
+	registered := regexp.MustCompile(`mux\.HandleFunc\("([^"]+)"`).FindAllSubmatch(src, -1)
+	if len(registered) == 0 {
+		t.Fatal("no routes found in app.go")
+	}
+	for _, m := range registered {
+		if route := string(m[1]); !covered[route] {
+			t.Errorf("route %q has no authorization test", route)
+		}
+	}
+}
+
+func TestBagRouteAuthorization(t *testing.T) {
+	s := newTestServer(t)
+
+	for _, vis := range visibilities {
+		for _, c := range bagRoutes {
+			for _, role := range roles {
+				t.Run(vis.name+"/"+c.route+"/"+role, func(t *testing.T) {
+					f := s.seed(t, vis)
+					rec := s.do(t, role, c.routeCase, f)
+					expectStatus(t, rec, c.want(vis, role))
+				})
+			}
+		}
+	}
+}
+
+func TestUserRouteAuthorization(t *testing.T) {
+	s := newTestServer(t)
+
+	for _, c := range userRoutes {
+		for i, role := range roles {
+			t.Run(c.route+"/"+role, func(t *testing.T) {
+				f := s.seed(t, visibilities[0])
+				rec := s.do(t, role, c.routeCase, f)
+				expectStatus(t, rec, c.want[i])
+			})
+		}
+	}
+}
+
+const moveDiscRoute = "PATCH /discs/{id}"
+
+// Moving a disc needs ownership of the target bag too. The target here
+// belongs to the stranger and is shared with the owner in the shared case.
+func TestMoveDiscIntoUnownedBag(t *testing.T) {
+	s := newTestServer(t)
+
+	targets := []struct {
+		visibility
+		want int
+	}{
+		{visibility{name: "public", visibility: bag.VisibilityPublic}, http.StatusForbidden},
+		{visibility{name: "private", visibility: bag.VisibilityPrivate}, http.StatusNotFound},
+		{visibility{name: "shared", visibility: bag.VisibilityPrivate, viewerIDs: []string{testOwner}}, http.StatusForbidden},
+	}
+
+	for _, target := range targets {
+		t.Run(target.name, func(t *testing.T) {
+			f := s.seed(t, visibilities[0])
+			f[":target"] = s.mustCreate(t, roleStranger, routeCase{
+				route:  "POST /bags",
+				target: "/bags",
+				body:   bagBody("Stranger Bag", target.visibility),
+			}, f)
+
+			move := routeCase{route: moveDiscRoute, target: "/discs/:disc", body: `{"bagId":":target"}`}
+			expectStatus(t, s.do(t, roleOwner, move, f), target.want)
+
+			rec := s.do(t, roleOwner, routeCase{route: "GET /bags/{id}/discs", target: "/bags/:bag/discs"}, f)
+			var discs []map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &discs); err != nil || len(discs) != 1 {
+				t.Errorf("disc left its bag after a rejected move: %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+// Copies of someone else's bag, bags made from templates and imported bags
+// start private; the owner's own clones keep the source's visibility.
+func TestCopiedBagVisibility(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		source visibility
+		role   string
+		copy   routeCase
+		want   string
+	}{
+		{"owner clones public", visibilities[0], roleOwner, routeCase{target: "/bags/:bag/clone"}, bag.VisibilityPublic},
+		{"owner clones private", visibilities[1], roleOwner, routeCase{target: "/bags/:bag/clone"}, bag.VisibilityPrivate},
+		{"stranger clones public", visibilities[0], roleStranger, routeCase{target: "/bags/:bag/clone"}, bag.VisibilityPrivate},
+		{"viewer clones shared", visibilities[2], roleViewer, routeCase{target: "/bags/:bag/clone"}, bag.VisibilityPrivate},
+		{"owner instantiates template", visibilities[0], roleOwner, routeCase{target: "/bag-templates/:template/instantiate"}, bag.VisibilityPrivate},
+		{"owner imports a bag", visibilities[0], roleOwner, routeCase{target: "/bags/import", upload: bagImportUpload}, bag.VisibilityPrivate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := s.seed(t, tt.source)
+			out := s.mustDo(t, tt.role, http.MethodPost, tt.copy, f)
+
+			created, _ := out["bag"].(map[string]any)
+			if got := created["visibility"]; got != tt.want {
+				t.Errorf("visibility = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Deleting a bag handles the discs in it according to the mode; deleted
+// discs take their photos with them. A lost disc still linked to the bag is
+// only detached, whatever the mode.
+func TestDeleteBagModes(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		mode        string
+		want        map[string]float64
+		targetDiscs int
+	}{
+		{bag.DeleteModeShelve, map[string]float64{"discsShelved": 1, "discsDetached": 1}, 0},
+		{bag.DeleteModeMove, map[string]float64{"discsMoved": 1, "discsDetached": 1}, 1},
+		{bag.DeleteModeDelete, map[string]float64{"discsDeleted": 1, "discsDetached": 1}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			f := s.seed(t, visibilities[0])
+			f[":target"] = s.mustCreate(t, roleOwner, routeCase{target: "/bags", body: bagBody("Target Bag", visibilities[0])}, f)
+			f[":lost"] = s.mustCreate(t, roleOwner, routeCase{target: "/bags/:bag/discs", body: `{"catalogDiscId":":catalog"}`}, f)
+			s.mustDo(t, roleOwner, http.MethodPut, routeCase{target: "/discs/:lost/status", body: `{"status":"lost"}`}, f)
+
+			target := "/bags/:bag?mode=" + tt.mode
+			if tt.mode == bag.DeleteModeMove {
+				target += "&targetBagId=:target"
+			}
+			summary := s.mustDo(t, roleOwner, http.MethodDelete, routeCase{target: target}, f)
+			for _, field := range []string{"discsMoved", "discsShelved", "discsDeleted", "discsDetached"} {
+				if summary[field] != tt.want[field] {
+					t.Errorf("%s = %v, want %v", field, summary[field], tt.want[field])
+				}
+			}
+
+			lostID, _ := bson.ObjectIDFromHex(f[":lost"])
+			var lost bson.M
+			if err := s.db.Collection("discs").FindOne(context.Background(), bson.M{"_id": lostID}).Decode(&lost); err != nil {
+				t.Fatalf("lost disc: %v", err)
+			}
+			if lost["status"] != "lost" || lost["bagId"] != nil {
+				t.Errorf("lost disc status = %v, bagId = %v, want lost and no bag", lost["status"], lost["bagId"])
+			}
+
+			rec := s.do(t, roleOwner, routeCase{route: "GET /bags/{id}/discs", target: "/bags/:target/discs"}, f)
+			var discs []map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &discs); err != nil || len(discs) != tt.targetDiscs {
+				t.Errorf("target bag discs = %s, want %d", rec.Body.String(), tt.targetDiscs)
+			}
+
+			wantPhotos := tt.mode != bag.DeleteModeDelete
+			for _, key := range []string{f.fill("discs/:disc/:photo.png"), f.fill("discs/:disc/:photo_thumb.jpg")} {
+				blob, _, err := s.blobs.Get(context.Background(), key)
+				if err == nil {
+					blob.Close()
+				}
+				if kept := err == nil; kept != wantPhotos {
+					t.Errorf("blob %s kept = %v, want %v", key, kept, wantPhotos)
+				}
+			}
+		})
+	}
+}
+
+// Custom discs rank on the same scale as catalog discs: a plain catalog
+// name match beats a custom disc that only contains the word.
+func TestSearchRanksCustomDiscsWithCatalog(t *testing.T) {
+	s := newTestServer(t)
+	f := s.seed(t, visibilities[0])
+	s.mustCreate(t, roleOwner, routeCase{target: "/catalog/custom-discs", body: fmt.Sprintf(customDiscBody, "Destroyer Proto")}, f)
+
+	page := s.mustDo(t, roleOwner, http.MethodGet, routeCase{target: "/catalog/discs?q=destroyer"}, f)
+	discs, _ := page["discs"].([]any)
+
+	var names []string
+	for _, d := range discs {
+		disc, _ := d.(map[string]any)
+		name, _ := disc["name"].(string)
+		names = append(names, name)
+	}
+	if !slices.Equal(names, []string{"Destroyer", "Destroyer Proto"}) {
+		t.Errorf("results = %v, want [Destroyer Destroyer Proto]", names)
+	}
+}
+
+// Clearing an override puts the DiscIt value back and shows up in the
+// audit trail. The seeded override sets speed 12 -> 11.
+func TestClearCatalogOverride(t *testing.T) {
+	s := newTestServer(t)
+	f := s.seed(t, visibilities[0])
+
+	cleared := s.mustDo(t, roleOwner, http.MethodDelete, routeCase{target: "/catalog/discs/:catalog/override?field=speed"}, f)
+	if got := cleared["speed"]; got != float64(12) {
+		t.Errorf("speed after clear = %v, want 12", got)
+	}
+
+	override := s.mustDo(t, roleOwner, http.MethodGet, routeCase{target: "/catalog/discs/:catalog/override"}, f)
+	if fields, _ := override["fields"].(map[string]any); fields["speed"] != nil {
+		t.Errorf("speed override still set: %v", fields["speed"])
+	}
+
+	audit, _ := override["audit"].([]any)
+	if len(audit) != 2 {
+		t.Fatalf("len(audit) = %d, want 2", len(audit))
+	}
+	last, _ := audit[1].(map[string]any)
+	changes, _ := last["changes"].([]any)
+	if len(changes) != 1 {
+		t.Fatalf("len(changes) = %d, want 1", len(changes))
+	}
+	change, _ := changes[0].(map[string]any)
+	if change["field"] != "speed" || change["before"] != float64(11) || change["after"] != float64(12) || change["cleared"] != true {
+		t.Errorf("clear audit = %v", change)
+	}
+}
+
+type testServer struct {
+	db      *database.MongoDB
+	blobs   storage.BlobStore
+	handler http.Handler
+	cookies map[string]*http.Cookie
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI is not set")
+	}
+
+	dbName := "zack_test_" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	db, err := database.NewMongoDB(uri, dbName)
+	if err != nil {
+		t.Fatalf("failed to connect to MongoDB: %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = db.Database.Drop(ctx)
+		_ = db.Close(ctx)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := migrations.Run(ctx, db.Database); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create blob store: %v", err)
+	}
+
+	cfg := &config.Config{
+		Environment:    "test",
+		AuthJWTSecret:  "test-secret",
+		AuthBypassCode: "000000",
+		OwnerUserID:    testOwner,
+		AllowedOrigin:  "http://localhost:5173",
+	}
+
+	authService := services.NewAuthService(cfg)
+	cookies := make(map[string]*http.Cookie, len(roleUsers))
+	for role, userID := range roleUsers {
+		token, err := authService.GenerateToken(userID)
+		if err != nil {
+			t.Fatalf("failed to create token: %v", err)
+		}
+		cookies[role] = &http.Cookie{Name: "auth_token", Value: token.Token}
+	}
+
+	return &testServer{
+		db:      db,
+		blobs:   blobs,
+		handler: New(cfg, db, blobs).Routes(),
+		cookies: cookies,
+	}
+}
+
+type fixture map[string]string
+
+func (f fixture) fill(s string) string {
+	pairs := make([]string, 0, len(f)*2)
+	for k, v := range f {
+		pairs = append(pairs, k, v)
+	}
+	return strings.NewReplacer(pairs...).Replace(s)
+}
+
+// seed empties every collection and creates, as the owner, one of
+// everything the routes act on: a bag with vis and a disc with a photo,
+// a share link, a template, a custom disc, a catalog override, a finished
+// and an open round, an alias and a league with a season and an event.
+func (s *testServer) seed(t *testing.T, vis visibility) fixture {
+	t.Helper()
+
+	ctx := context.Background()
+	names, err := s.db.Database.ListCollectionNames(ctx, bson.D{})
+	if err != nil {
+		t.Fatalf("failed to list collections: %v", err)
+	}
+	for _, name := range names {
+		if name == "schema_migrations" {
+			continue
+		}
+		if _, err := s.db.Collection(name).DeleteMany(ctx, bson.D{}); err != nil {
+			t.Fatalf("failed to clear %s: %v", name, err)
+		}
+	}
+
+	_, err = s.db.Collection("disc_catalog").InsertOne(ctx, catalog.CatalogDisc{
+		ID:           testCatalogID,
+		DiscItID:     "test-destroyer",
+		Name:         "Destroyer",
+		Brand:        "Innova",
+		Category:     "Distance Driver",
+		Speed:        12,
+		Glide:        5,
+		Turn:         -1,
+		Fade:         3,
+		Stability:    "Stable",
+		NameSlug:     "destroyer",
+		BrandSlug:    "innova",
+		CategorySlug: "distance-driver",
+	})
+	if err != nil {
+		t.Fatalf("failed to insert catalog disc: %v", err)
+	}
+
+	f := fixture{":catalog": testCatalogID.Hex()}
+	create := func(target, body string) string {
+		return s.mustCreate(t, roleOwner, routeCase{target: target, body: body}, f)
+	}
+	call := func(method, target, body string) {
+		s.mustDo(t, roleOwner, method, routeCase{target: target, body: body}, f)
+	}
+
+	f[":bag"] = create("/bags", bagBody("Fixture Bag", vis))
+	f[":disc"] = create("/bags/:bag/discs", `{"catalogDiscId":":catalog"}`)
+	f[":photo"] = s.mustCreate(t, roleOwner, routeCase{target: "/discs/:disc/photos", upload: photoUpload}, f)
+	f[":share"] = s.mustDo(t, roleOwner, http.MethodPost, routeCase{target: "/bags/:bag/shares", body: `{}`}, f)["token"].(string)
+	f[":template"] = create("/bags/:bag/template", `{"name":"Fixture Template"}`)
+	f[":custom"] = create("/catalog/custom-discs", fmt.Sprintf(customDiscBody, "Fixture Proto"))
+	call(http.MethodPatch, "/catalog/discs/:catalog", `{"speed":11}`)
+
+	roundBody := `{"courseName":"Fixture Park","layoutName":"Main","pars":[3,3],"players":["Owner"]}`
+	f[":round"] = create("/udisc/rounds", roundBody)
+	call(http.MethodPatch, "/udisc/rounds/:round/scores", `{"playerName":"Owner","hole":1,"score":3}`)
+	call(http.MethodPatch, "/udisc/rounds/:round/scores", `{"playerName":"Owner","hole":2,"score":4}`)
+	call(http.MethodPost, "/udisc/rounds/:round/finalize", "")
+	f[":liveround"] = create("/udisc/rounds", roundBody)
+	call(http.MethodPost, "/udisc/players/aliases", `{"alias":"Ownr","canonicalName":"Owner"}`)
+
+	f[":league"] = create("/leagues", `{"name":"Fixture League"}`)
+	f[":season"] = create("/leagues/:league/seasons", `{"name":"Summer","startDate":"2026-05-01","endDate":"2026-08-31"}`)
+	f[":event"] = create("/league-seasons/:season/events", `{"name":"Week 1","date":"2026-06-01"}`)
+
+	return f
+}
+
+func (s *testServer) do(t *testing.T, role string, c routeCase, f fixture) *httptest.ResponseRecorder {
+	t.Helper()
+
+	method, _, _ := strings.Cut(c.route, " ")
+	return s.request(t, role, method, c, f)
+}
+
+func (s *testServer) request(t *testing.T, role, method string, c routeCase, f fixture) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var body io.Reader
+	contentType := ""
+	switch {
+	case c.upload != nil:
+		u := c.upload()
+		var buf bytes.Buffer
+		mw := multipart.NewWriter(&buf)
+		part, err := mw.CreateFormFile(u.field, u.filename)
+		if err != nil {
+			t.Fatalf("failed to build upload: %v", err)
+		}
+		_, _ = part.Write(u.data)
+		for k, v := range u.values {
+			_ = mw.WriteField(k, v)
+		}
+		_ = mw.Close()
+		body, contentType = &buf, mw.FormDataContentType()
+	case c.body != "":
+		body, contentType = strings.NewReader(f.fill(c.body)), "application/json"
+	}
+
+	req := httptest.NewRequest(method, f.fill(c.target), body)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if cookie, ok := s.cookies[role]; ok {
+		req.AddCookie(cookie)
+	}
+
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *testServer) mustDo(t *testing.T, role, method string, c routeCase, f fixture) map[string]any {
+	t.Helper()
+
+	rec := s.request(t, role, method, c, f)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("fixture %s %s: status %d: %s", method, f.fill(c.target), rec.Code, rec.Body.String())
+	}
+
+	out := map[string]any{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+		t.Fatalf("fixture %s %s: failed to decode response: %v", method, f.fill(c.target), err)
+	}
+	return out
+}
+
+func (s *testServer) mustCreate(t *testing.T, role string, c routeCase, f fixture) string {
+	t.Helper()
+
+	id, _ := s.mustDo(t, role, http.MethodPost, c, f)["_id"].(string)
+	if id == "" {
+		t.Fatalf("fixture POST %s: response has no _id", f.fill(c.target))
+	}
+	return id
+}
+
+func expectStatus(t *testing.T, rec *httptest.ResponseRecorder, want int) {
+	t.Helper()
+
+	// ServeMux answers unregistered paths in plain text.
+	if strings.HasPrefix(rec.Body.String(), "404 page not found") {
+		t.Fatalf("route is not registered")
+	}
+
+	got := rec.Code
+	if want == allowed {
+		if got == http.StatusUnauthorized || got == http.StatusForbidden || got == http.StatusNotFound || got >= 500 {
+			t.Errorf("status = %d, want an allowed response: %s", got, rec.Body.String())
+		}
+		return
+	}
+	if got != want {
+		t.Errorf("status = %d, want %d: %s", got, want, rec.Body.String())
+	}
+}
+
+func bagBody(name string, vis visibility) string {
+	body, _ := json.Marshal(map[string]any{
+		"name":       name,
+		"visibility": vis.visibility,
+		"viewerIds":  vis.viewerIDs,
+	})
+	return string(body)
+}
+
+func photoUpload() *upload {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for x := range 4 {
+		for y := range 4 {
+			img.Set(x, y, color.RGBA{R: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, img)
+	return &upload{field: "photo", filename: "disc.png", data: buf.Bytes()}
+}
+
+func bagImportUpload() *upload {
+	return &upload{
+		field:    "file",
+		filename: "bag.csv",
+		data:     []byte("brand,name\nInnova,Destroyer\n"),
+		values:   map[string]string{"name": "Imported Bag"},
+	}
+}
+
+func techDiscUpload() *upload {
+	return &upload{
+		field:    "file",
+		filename: "throws.csv",
+		data:     []byte("id,time,timeSeconds,speedMph,spinRpm,throwType,primaryThrowType\n1,2026-06-01 10:00,1780308000,52.5,950,backhand,backhand\n"),
+		values:   map[string]string{"handedness": "right"},
+	}
+}
+
+func udiscUpload() *upload {
+	return &upload{
+		field:    "file",
+		filename: "rounds.csv",
+		data: []byte("PlayerName,CourseName,LayoutName,StartDate,EndDate,Total,+/-,Hole1,Hole2\n" +
+			"Par,Import Park,Main,2026-06-01 1000,2026-06-01 1100,6,,3,3\n" +
+			"Owner,Import Park,Main,2026-06-01 1000,2026-06-01 1100,7,+1,3,4\n"),
+	}
+}
@@ -0,0 +1,47 @@
+package bag
+
+import "slices"
+
+const (
+	VisibilityPrivate = "private"
+	VisibilityPublic  = "public"
+)
+
+// Access is what a caller may do with a bag. Levels are ordered so callers
+// can compare against the minimum they need.
+type Access int
+
+const (
+	AccessNone Access = iota
+	AccessViewer
+	AccessOwner
+)
+
+// AccessFor resolves the caller's access. An empty userID is an anonymous
+// caller. Bags saved before visibility existed have none set and stay public.
+func (b *Bag) AccessFor(userID string) Access {
+	if userID != "" && b.UserID == userID {
+		return AccessOwner
+	}
+
+	if b.Visibility == VisibilityPublic || b.Visibility == "" {
+		return AccessViewer
+	}
+
+	if userID != "" && slices.Contains(b.ViewerIDs, userID) {
+		return AccessViewer
+	}
+
+	return AccessNone
+}
+
+// ForCaller returns the bag as the caller may see it. The viewer list is the
+// owner's business, so everyone else gets a copy without it.
+func (b *Bag) ForCaller(userID string) *Bag {
+	if b.AccessFor(userID) == AccessOwner {
+		return b
+	}
+	visible := *b
+	visible.ViewerIDs = nil
+	return &visible
+}
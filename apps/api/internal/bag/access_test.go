@@ -0,0 +1,61 @@
+package bag
+
+import "testing"
+
+func TestAccessFor(t *testing.T) {
+	const (
+		owner    = "owner-1"
+		viewer   = "viewer-1"
+		stranger = "stranger-1"
+		anon     = ""
+	)
+
+	bags := map[string]*Bag{
+		"public":         {UserID: owner, Visibility: VisibilityPublic},
+		"legacy":         {UserID: owner},
+		"private":        {UserID: owner, Visibility: VisibilityPrivate},
+		"private viewer": {UserID: owner, Visibility: VisibilityPrivate, ViewerIDs: []string{viewer}},
+	}
+
+	tests := []struct {
+		bag    string
+		caller string
+		want   Access
+	}{
+		{"public", owner, AccessOwner},
+		{"public", viewer, AccessViewer},
+		{"public", stranger, AccessViewer},
+		{"public", anon, AccessViewer},
+
+		{"legacy", owner, AccessOwner},
+		{"legacy", stranger, AccessViewer},
+		{"legacy", anon, AccessViewer},
+
+		{"private", owner, AccessOwner},
+		{"private", viewer, AccessNone},
+		{"private", stranger, AccessNone},
+		{"private", anon, AccessNone},
+
+		{"private viewer", owner, AccessOwner},
+		{"private viewer", viewer, AccessViewer},
+		{"private viewer", stranger, AccessNone},
+		{"private viewer", anon, AccessNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.bag+"/"+tt.caller, func(t *testing.T) {
+			if got := bags[tt.bag].AccessFor(tt.caller); got != tt.want {
+				t.Errorf("AccessFor(%q) = %d, want %d", tt.caller, got, tt.want)
+			}
+		})
+	}
+}
+
+// An anonymous caller must never match a bag whose owner or viewer list
+// holds an empty ID.
+func TestAccessForEmptyIDs(t *testing.T) {
+	b := &Bag{UserID: "", Visibility: VisibilityPrivate, ViewerIDs: []string{""}}
+	if got := b.AccessFor(""); got != AccessNone {
+		t.Errorf("AccessFor(\"\") = %d, want %d", got, AccessNone)
+	}
+}
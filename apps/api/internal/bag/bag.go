@@ -7,19 +7,31 @@ import (
 )
 
 type Bag struct {
-	ID        bson.ObjectID `bson:"_id,omitempty" json:"_id"`
-	UserID    string        `bson:"userId" json:"userId"`
-	Name      string        `bson:"name" json:"name"`
-	CreatedAt time.Time     `bson:"createdAt" json:"createdAt"`
-	UpdatedAt time.Time     `bson:"updatedAt" json:"updatedAt"`
+	ID     bson.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID string        `bson:"userId" json:"userId"`
+	Name   string        `bson:"name" json:"name"`
+
+	Visibility string   `bson:"visibility" json:"visibility"`
+	ViewerIDs  []string `bson:"viewerIds,omitempty" json:"viewerIds,omitempty"`
+
+	// Only exposed to the owner through the share endpoints.
+	ShareTokens []ShareToken `bson:"shareTokens,omitempty" json:"-"`
+
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
 }
 
 type CreateBagInput struct {
-	Name string `json:"name"`
+	Name       string   `json:"name"`
+	Visibility string   `json:"visibility"`
+	ViewerIDs  []string `json:"viewerIds"`
 }
 
+// Nil fields are left unchanged.
 type UpdateBagInput struct {
-	Name string `json:"name"`
+	Name       *string   `json:"name"`
+	Visibility *string   `json:"visibility"`
+	ViewerIDs  *[]string `json:"viewerIds"`
 }
 
 type BagWithDiscs struct {
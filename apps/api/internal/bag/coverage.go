@@ -0,0 +1,112 @@
+package bag
+
+import (
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+	"github.com/Tidwell32/zack/apps/api/internal/disc"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// A cell with this many discs or more is reported as an overlap.
+const overlapThreshold = 3
+
+// Band is an inclusive range on one axis of the flight chart. Flight numbers
+// move in half steps, so consecutive bands leave no values uncovered.
+type Band struct {
+	Label string  `json:"label"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+}
+
+var SpeedBands = []Band{
+	{Label: "putter", Min: 1, Max: 3.5},
+	{Label: "midrange", Min: 4, Max: 5.5},
+	{Label: "fairway", Min: 6, Max: 8.5},
+	{Label: "control driver", Min: 9, Max: 10.5},
+	{Label: "distance driver", Min: 11, Max: 15},
+}
+
+// Stability bands use turn + fade.
+var StabilityBands = []Band{
+	{Label: "understable", Min: -6, Max: -1.5},
+	{Label: "neutral", Min: -1, Max: 0.5},
+	{Label: "stable", Min: 1, Max: 2.5},
+	{Label: "overstable", Min: 3, Max: 8},
+}
+
+type CoverageCell struct {
+	Speed     Band            `json:"speed"`
+	Stability Band            `json:"stability"`
+	DiscIDs   []bson.ObjectID `json:"discIds"`
+	Count     int             `json:"count"`
+}
+
+type CoverageGap struct {
+	CoverageCell
+	Suggestions []*catalog.CatalogDisc `json:"suggestions"`
+}
+
+type GapAnalysis struct {
+	BagID     bson.ObjectID  `json:"bagId"`
+	DiscCount int            `json:"discCount"`
+	Cells     []CoverageCell `json:"cells"`
+	Gaps      []CoverageGap  `json:"gaps"`
+	Overlaps  []CoverageCell `json:"overlaps"`
+}
+
+// AnalyzeCoverage buckets each disc's effective flight into the speed ×
+// stability grid. Gaps are empty cells; overlaps are crowded ones. Gap
+// suggestions are left for the caller to fill in.
+func AnalyzeCoverage(bagID bson.ObjectID, discs []*disc.Disc) *GapAnalysis {
+	cells := make([]CoverageCell, 0, len(SpeedBands)*len(StabilityBands))
+	for _, speed := range SpeedBands {
+		for _, stability := range StabilityBands {
+			cells = append(cells, CoverageCell{
+				Speed:     speed,
+				Stability: stability,
+				DiscIDs:   []bson.ObjectID{},
+			})
+		}
+	}
+
+	for _, d := range discs {
+		flight := d.EffectiveFlight()
+		si := bandIndex(SpeedBands, flight.Speed)
+		ti := bandIndex(StabilityBands, flight.Stability())
+
+		cell := &cells[si*len(StabilityBands)+ti]
+		cell.DiscIDs = append(cell.DiscIDs, d.ID)
+		cell.Count++
+	}
+
+	analysis := &GapAnalysis{
+		BagID:     bagID,
+		DiscCount: len(discs),
+		Cells:     cells,
+		Gaps:      []CoverageGap{},
+		Overlaps:  []CoverageCell{},
+	}
+
+	for _, cell := range cells {
+		switch {
+		case cell.Count == 0:
+			analysis.Gaps = append(analysis.Gaps, CoverageGap{
+				CoverageCell: cell,
+				Suggestions:  []*catalog.CatalogDisc{},
+			})
+		case cell.Count >= overlapThreshold:
+			analysis.Overlaps = append(analysis.Overlaps, cell)
+		}
+	}
+
+	return analysis
+}
+
+// bandIndex clamps values outside the chart into the first or last band.
+func bandIndex(bands []Band, value float64) int {
+	for i, b := range bands {
+		if value <= b.Max {
+			return i
+		}
+	}
+	return len(bands) - 1
+}
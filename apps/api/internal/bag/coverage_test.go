@@ -0,0 +1,88 @@
+package bag
+
+import (
+	"testing"
+
+	"github.com/Tidwell32/zack/apps/api/internal/disc"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func flightDisc(speed, turn, fade float64) *disc.Disc {
+	return &disc.Disc{ID: bson.NewObjectID(), StockFlight: disc.FlightNumbers{Speed: speed, Glide: 5, Turn: turn, Fade: fade}}
+}
+
+func TestAnalyzeCoverageCells(t *testing.T) {
+	adjusted := flightDisc(12, -1, 3)
+	adjusted.AdjustedFlight = &disc.FlightNumbers{Speed: 12, Glide: 5, Turn: -2, Fade: 1}
+
+	tests := []struct {
+		name      string
+		disc      *disc.Disc
+		speed     string
+		stability string
+	}{
+		{"distance driver", flightDisc(12, -1, 3), "distance driver", "stable"},
+		{"midrange", flightDisc(5, -1, 1), "midrange", "neutral"},
+		{"top of a speed band", flightDisc(3.5, 0, 0.5), "putter", "neutral"},
+		{"bottom of a speed band", flightDisc(4, 0, 0.5), "midrange", "neutral"},
+		{"top of a stability band", flightDisc(7, -3, 1.5), "fairway", "understable"},
+		{"bottom of a stability band", flightDisc(7, -2, 1), "fairway", "neutral"},
+		{"below the chart", flightDisc(0.5, -5, 0), "putter", "understable"},
+		{"above the chart", flightDisc(16, 0, 6), "distance driver", "overstable"},
+		{"adjusted flight wins", adjusted, "distance driver", "neutral"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := AnalyzeCoverage(bson.NewObjectID(), []*disc.Disc{tt.disc})
+
+			var filled []CoverageCell
+			for _, cell := range analysis.Cells {
+				if cell.Count > 0 {
+					filled = append(filled, cell)
+				}
+			}
+			if len(filled) != 1 {
+				t.Fatalf("%d cells filled, want 1", len(filled))
+			}
+			if got := filled[0]; got.Speed.Label != tt.speed || got.Stability.Label != tt.stability {
+				t.Errorf("cell = %s/%s, want %s/%s", got.Speed.Label, got.Stability.Label, tt.speed, tt.stability)
+			}
+		})
+	}
+}
+
+func TestAnalyzeCoverageGapsAndOverlaps(t *testing.T) {
+	cells := len(SpeedBands) * len(StabilityBands)
+
+	tests := []struct {
+		name     string
+		discs    []*disc.Disc
+		gaps     int
+		overlaps int
+	}{
+		{"empty bag", nil, cells, 0},
+		{"one disc", []*disc.Disc{flightDisc(2, 0, 1)}, cells - 1, 0},
+		{"two in a cell", []*disc.Disc{flightDisc(2, 0, 1), flightDisc(3, -1, 2)}, cells - 1, 0},
+		{"three in a cell", []*disc.Disc{flightDisc(2, 0, 1), flightDisc(3, -1, 2), flightDisc(2, 0.5, 2)}, cells - 1, 1},
+		{"spread out", []*disc.Disc{flightDisc(2, 0, 1), flightDisc(5, 0, 3), flightDisc(12, -2, 1)}, cells - 3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := AnalyzeCoverage(bson.NewObjectID(), tt.discs)
+
+			if analysis.DiscCount != len(tt.discs) || len(analysis.Cells) != cells {
+				t.Errorf("DiscCount = %d, %d cells", analysis.DiscCount, len(analysis.Cells))
+			}
+			if len(analysis.Gaps) != tt.gaps || len(analysis.Overlaps) != tt.overlaps {
+				t.Errorf("%d gaps and %d overlaps, want %d and %d", len(analysis.Gaps), len(analysis.Overlaps), tt.gaps, tt.overlaps)
+			}
+			for _, gap := range analysis.Gaps {
+				if gap.Count != 0 || gap.Suggestions == nil {
+					t.Errorf("gap %s/%s: count %d, suggestions %v", gap.Speed.Label, gap.Stability.Label, gap.Count, gap.Suggestions)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package bag
+
+import "go.mongodb.org/mongo-driver/v2/bson"
+
+// What happens to a bag's discs when the bag is deleted.
+const (
+	DeleteModeMove   = "move"
+	DeleteModeShelve = "shelve"
+	DeleteModeDelete = "delete"
+)
+
+var DeleteModes = []string{DeleteModeMove, DeleteModeShelve, DeleteModeDelete}
+
+type DeleteOptions struct {
+	Mode string
+	// Required for DeleteModeMove.
+	TargetBagID *bson.ObjectID
+}
+
+// DeleteSummary reports what happened to the bag's discs. Discs that were
+// only linked to the bag (lost, retired, sold, backup) are detached rather
+// than moved or deleted.
+type DeleteSummary struct {
+	BagID         bson.ObjectID  `json:"bagId"`
+	Mode          string         `json:"mode"`
+	TargetBagID   *bson.ObjectID `json:"targetBagId,omitempty"`
+	DiscsMoved    int64          `json:"discsMoved"`
+	DiscsShelved  int64          `json:"discsShelved"`
+	DiscsDeleted  int64          `json:"discsDeleted"`
+	DiscsDetached int64          `json:"discsDetached"`
+}
+
+// OrphanedBag is a bag ID still referenced by discs after the bag itself
+// was deleted.
+type OrphanedBag struct {
+	BagID         bson.ObjectID `json:"bagId"`
+	DiscsShelved  int64         `json:"discsShelved"`
+	DiscsDetached int64         `json:"discsDetached"`
+}
+
+type OrphanReport struct {
+	DryRun bool          `json:"dryRun"`
+	Bags   []OrphanedBag `json:"bags"`
+}
@@ -0,0 +1,94 @@
+package bag
+
+import (
+	"time"
+
+	"github.com/Tidwell32/zack/apps/api/internal/disc"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ShareToken grants read-only access to a bag through a link, regardless of
+// the bag's visibility.
+type ShareToken struct {
+	Token     string     `bson:"token" json:"token"`
+	CreatedAt time.Time  `bson:"createdAt" json:"createdAt"`
+	ExpiresAt *time.Time `bson:"expiresAt,omitempty" json:"expiresAt,omitempty"`
+}
+
+func (t ShareToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// SharedDisc is a disc as shown on a share page. Notes and ownership fields
+// are left out on purpose.
+type SharedDisc struct {
+	ID       bson.ObjectID `json:"_id"`
+	Name     string        `json:"name"`
+	Brand    string        `json:"brand"`
+	Category string        `json:"category"`
+	Plastic  string        `json:"plastic,omitempty"`
+	Weight   *int          `json:"weight,omitempty"`
+	ColorHex string        `json:"colorHex,omitempty"`
+
+	StockFlight    disc.FlightNumbers  `json:"stockFlight"`
+	AdjustedFlight *disc.FlightNumbers `json:"adjustedFlight,omitempty"`
+}
+
+// FlightChartPoint places a disc on the speed × stability chart using its
+// effective flight numbers.
+type FlightChartPoint struct {
+	DiscID    bson.ObjectID `json:"discId"`
+	Name      string        `json:"name"`
+	ColorHex  string        `json:"colorHex,omitempty"`
+	Speed     float64       `json:"speed"`
+	Glide     float64       `json:"glide"`
+	Turn      float64       `json:"turn"`
+	Fade      float64       `json:"fade"`
+	Stability float64       `json:"stability"`
+}
+
+type SharedBag struct {
+	Name        string             `json:"name"`
+	Discs       []SharedDisc       `json:"discs"`
+	FlightChart []FlightChartPoint `json:"flightChart"`
+	Coverage    []CoverageCell     `json:"coverage"`
+	ExpiresAt   *time.Time         `json:"expiresAt,omitempty"`
+}
+
+func NewSharedBag(b *Bag, token ShareToken, discs []*disc.Disc) *SharedBag {
+	shared := &SharedBag{
+		Name:        b.Name,
+		Discs:       make([]SharedDisc, 0, len(discs)),
+		FlightChart: make([]FlightChartPoint, 0, len(discs)),
+		Coverage:    AnalyzeCoverage(b.ID, discs).Cells,
+		ExpiresAt:   token.ExpiresAt,
+	}
+
+	for _, d := range discs {
+		shared.Discs = append(shared.Discs, SharedDisc{
+			ID:             d.ID,
+			Name:           d.Name,
+			Brand:          d.Brand,
+			Category:       d.Category,
+			Plastic:        d.Plastic,
+			Weight:         d.Weight,
+			ColorHex:       d.ColorHex,
+			StockFlight:    d.StockFlight,
+			AdjustedFlight: d.AdjustedFlight,
+		})
+
+		flight := d.EffectiveFlight()
+		shared.FlightChart = append(shared.FlightChart, FlightChartPoint{
+			DiscID:    d.ID,
+			Name:      d.Name,
+			ColorHex:  d.ColorHex,
+			Speed:     flight.Speed,
+			Glide:     flight.Glide,
+			Turn:      flight.Turn,
+			Fade:      flight.Fade,
+			Stability: flight.Stability(),
+		})
+	}
+
+	return shared
+}
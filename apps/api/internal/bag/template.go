@@ -0,0 +1,104 @@
+package bag
+
+import (
+	"time"
+
+	"github.com/Tidwell32/zack/apps/api/internal/disc"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// TemplateDisc is a snapshot of a disc's setup, detached from any bag, that
+// can be turned back into a new disc.
+type TemplateDisc struct {
+	CatalogDiscID string `bson:"catalogDiscId" json:"catalogDiscId"`
+
+	Name         string `bson:"name" json:"name"`
+	Brand        string `bson:"brand" json:"brand"`
+	Category     string `bson:"category" json:"category"`
+	NameSlug     string `bson:"nameSlug" json:"nameSlug"`
+	BrandSlug    string `bson:"brandSlug" json:"brandSlug"`
+	CategorySlug string `bson:"categorySlug" json:"categorySlug"`
+
+	Plastic  string `bson:"plastic,omitempty" json:"plastic,omitempty"`
+	Weight   *int   `bson:"weight,omitempty" json:"weight,omitempty"`
+	ColorHex string `bson:"colorHex,omitempty" json:"colorHex,omitempty"`
+	Notes    string `bson:"notes,omitempty" json:"notes,omitempty"`
+
+	StockFlight    disc.FlightNumbers  `bson:"stockFlight" json:"stockFlight"`
+	AdjustedFlight *disc.FlightNumbers `bson:"adjustedFlight,omitempty" json:"adjustedFlight,omitempty"`
+}
+
+// Template is a named bag setup, e.g. "tournament" or "windy day".
+type Template struct {
+	ID          bson.ObjectID  `bson:"_id,omitempty" json:"_id"`
+	UserID      string         `bson:"userId" json:"userId"`
+	Name        string         `bson:"name" json:"name"`
+	SourceBagID bson.ObjectID  `bson:"sourceBagId" json:"sourceBagId"`
+	Discs       []TemplateDisc `bson:"discs" json:"discs"`
+	CreatedAt   time.Time      `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time      `bson:"updatedAt" json:"updatedAt"`
+}
+
+func NewTemplateDisc(d *disc.Disc, includeNotes bool) TemplateDisc {
+	t := TemplateDisc{
+		CatalogDiscID:  d.CatalogDiscID,
+		Name:           d.Name,
+		Brand:          d.Brand,
+		Category:       d.Category,
+		NameSlug:       d.NameSlug,
+		BrandSlug:      d.BrandSlug,
+		CategorySlug:   d.CategorySlug,
+		Plastic:        d.Plastic,
+		Weight:         d.Weight,
+		ColorHex:       d.ColorHex,
+		StockFlight:    d.StockFlight,
+		AdjustedFlight: d.AdjustedFlight,
+	}
+	if includeNotes {
+		t.Notes = d.Notes
+	}
+	return t
+}
+
+// NewDisc creates a fresh in-bag disc from the snapshot. History starts over
+// since the new disc hasn't been thrown yet.
+func (t TemplateDisc) NewDisc(userID string, bagID bson.ObjectID, now time.Time) *disc.Disc {
+	d := &disc.Disc{
+		ID:            bson.NewObjectID(),
+		UserID:        userID,
+		BagID:         bagID,
+		CatalogDiscID: t.CatalogDiscID,
+
+		Name:         t.Name,
+		Brand:        t.Brand,
+		Category:     t.Category,
+		NameSlug:     t.NameSlug,
+		BrandSlug:    t.BrandSlug,
+		CategorySlug: t.CategorySlug,
+
+		Plastic:  t.Plastic,
+		Weight:   t.Weight,
+		ColorHex: t.ColorHex,
+		Notes:    t.Notes,
+
+		StockFlight: t.StockFlight,
+
+		Status: disc.StatusInBag,
+		StatusHistory: []disc.StatusChange{
+			{Status: disc.StatusInBag, ChangedAt: now},
+		},
+
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if t.AdjustedFlight != nil {
+		flight := *t.AdjustedFlight
+		d.AdjustedFlight = &flight
+		d.FlightHistory = []disc.FlightChange{
+			{Flight: &flight, ChangedAt: now, Reason: "initial"},
+		}
+	}
+
+	return d
+}
@@ -0,0 +1,380 @@
+package bag
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Tidwell32/zack/apps/api/internal/disc"
+	"github.com/Tidwell32/zack/apps/api/pkg/validation"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+const exportVersion = 1
+
+var ErrInvalidImport = errors.New("invalid import file")
+
+// ExportedDisc carries the numbers the disc actually flies, adjusted or stock,
+// so other tools see the same chart we do.
+type ExportedDisc struct {
+	Brand    string             `json:"brand"`
+	Name     string             `json:"name"`
+	Category string             `json:"category,omitempty"`
+	Plastic  string             `json:"plastic,omitempty"`
+	Weight   *int               `json:"weight,omitempty"`
+	ColorHex string             `json:"colorHex,omitempty"`
+	Notes    string             `json:"notes,omitempty"`
+	Flight   disc.FlightNumbers `json:"flight"`
+}
+
+type Export struct {
+	Version    int            `json:"version"`
+	Name       string         `json:"name"`
+	ExportedAt time.Time      `json:"exportedAt"`
+	Discs      []ExportedDisc `json:"discs"`
+}
+
+// NewExport builds an export of the bag. Notes are only included for the
+// bag's owner.
+func NewExport(b *Bag, discs []*disc.Disc, includeNotes bool) *Export {
+	export := &Export{
+		Version:    exportVersion,
+		Name:       b.Name,
+		ExportedAt: time.Now().UTC(),
+		Discs:      make([]ExportedDisc, 0, len(discs)),
+	}
+
+	for _, d := range discs {
+		e := ExportedDisc{
+			Brand:    d.Brand,
+			Name:     d.Name,
+			Category: d.Category,
+			Plastic:  d.Plastic,
+			Weight:   d.Weight,
+			ColorHex: d.ColorHex,
+			Flight:   d.EffectiveFlight(),
+		}
+		if includeNotes {
+			e.Notes = d.Notes
+		}
+		export.Discs = append(export.Discs, e)
+	}
+
+	return export
+}
+
+var csvHeader = []string{"brand", "name", "category", "plastic", "weight", "color", "speed", "glide", "turn", "fade", "notes"}
+
+func (e *Export) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, d := range e.Discs {
+		weight := ""
+		if d.Weight != nil {
+			weight = strconv.Itoa(*d.Weight)
+		}
+		record := []string{
+			d.Brand,
+			d.Name,
+			d.Category,
+			d.Plastic,
+			weight,
+			d.ColorHex,
+			formatFlightNumber(d.Flight.Speed),
+			formatFlightNumber(d.Flight.Glide),
+			formatFlightNumber(d.Flight.Turn),
+			formatFlightNumber(d.Flight.Fade),
+			d.Notes,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatFlightNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// ImportedDisc is one disc read from an import file. Row is 1-based and
+// refers to the disc's position in the file, for reporting.
+type ImportedDisc struct {
+	Row      int                 `json:"row"`
+	Brand    string              `json:"brand"`
+	Name     string              `json:"name"`
+	Plastic  string              `json:"plastic,omitempty"`
+	Weight   *int                `json:"weight,omitempty"`
+	ColorHex string              `json:"colorHex,omitempty"`
+	Notes    string              `json:"notes,omitempty"`
+	Flight   *disc.FlightNumbers `json:"flight,omitempty"`
+}
+
+type ImportFile struct {
+	Name  string
+	Discs []ImportedDisc
+}
+
+// ParseImport reads our own JSON and CSV exports as well as CSVs from other
+// bag tools. An empty format is sniffed from the content.
+func ParseImport(data []byte, format string) (*ImportFile, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	if format == "" {
+		trimmed := bytes.TrimSpace(data)
+		if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+			format = FormatJSON
+		} else {
+			format = FormatCSV
+		}
+	}
+
+	var (
+		file *ImportFile
+		err  error
+	)
+	switch format {
+	case FormatJSON:
+		file, err = parseJSONImport(data)
+	case FormatCSV:
+		file, err = parseCSVImport(data)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidImport, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(file.Discs) == 0 {
+		return nil, fmt.Errorf("%w: no discs found", ErrInvalidImport)
+	}
+
+	return file, nil
+}
+
+// jsonImportDisc accepts our export shape plus the field names other tools
+// commonly use.
+type jsonImportDisc struct {
+	Brand        string              `json:"brand"`
+	Manufacturer string              `json:"manufacturer"`
+	Name         string              `json:"name"`
+	Mold         string              `json:"mold"`
+	Plastic      string              `json:"plastic"`
+	Weight       *float64            `json:"weight"`
+	ColorHex     string              `json:"colorHex"`
+	Color        string              `json:"color"`
+	Notes        string              `json:"notes"`
+	Flight       *disc.FlightNumbers `json:"flight"`
+	Speed        *float64            `json:"speed"`
+	Glide        *float64            `json:"glide"`
+	Turn         *float64            `json:"turn"`
+	Fade         *float64            `json:"fade"`
+}
+
+func parseJSONImport(data []byte) (*ImportFile, error) {
+	var wrapper struct {
+		Name  string           `json:"name"`
+		Discs []jsonImportDisc `json:"discs"`
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &wrapper.Discs); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+		}
+	} else if err := json.Unmarshal(trimmed, &wrapper); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+
+	file := &ImportFile{
+		Name:  strings.TrimSpace(wrapper.Name),
+		Discs: make([]ImportedDisc, 0, len(wrapper.Discs)),
+	}
+
+	for i, jd := range wrapper.Discs {
+		d := ImportedDisc{
+			Row:      i + 1,
+			Brand:    firstNonEmpty(jd.Brand, jd.Manufacturer),
+			Name:     firstNonEmpty(jd.Name, jd.Mold),
+			Plastic:  strings.TrimSpace(jd.Plastic),
+			ColorHex: normalizeColor(firstNonEmpty(jd.ColorHex, jd.Color)),
+			Notes:    strings.TrimSpace(jd.Notes),
+			Flight:   jd.Flight,
+		}
+		if jd.Weight != nil {
+			w := int(*jd.Weight + 0.5)
+			d.Weight = &w
+		}
+		if d.Flight == nil && jd.Speed != nil && jd.Glide != nil && jd.Turn != nil && jd.Fade != nil {
+			d.Flight = &disc.FlightNumbers{Speed: *jd.Speed, Glide: *jd.Glide, Turn: *jd.Turn, Fade: *jd.Fade}
+		}
+		file.Discs = append(file.Discs, d)
+	}
+
+	return file, nil
+}
+
+// Header names other tools use, keyed by our column. Headers are compared
+// with case, spacing and punctuation removed.
+var csvColumnAliases = map[string][]string{
+	"brand":   {"brand", "manufacturer", "make", "company"},
+	"name":    {"name", "mold", "disc", "discname", "model"},
+	"plastic": {"plastic", "plastictype"},
+	"weight":  {"weight", "weightg", "grams", "mass"},
+	"color":   {"color", "colour", "colorhex", "hex"},
+	"speed":   {"speed"},
+	"glide":   {"glide"},
+	"turn":    {"turn"},
+	"fade":    {"fade"},
+	"notes":   {"notes", "note", "comments", "comment"},
+}
+
+func parseCSVImport(data []byte) (*ImportFile, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidImport)
+	}
+
+	colIndex := make(map[string]int)
+	for i, header := range records[0] {
+		normalized := normalizeHeader(header)
+		for col, aliases := range csvColumnAliases {
+			if _, ok := colIndex[col]; ok {
+				continue
+			}
+			for _, alias := range aliases {
+				if normalized == alias {
+					colIndex[col] = i
+					break
+				}
+			}
+		}
+	}
+
+	if _, ok := colIndex["name"]; !ok {
+		return nil, fmt.Errorf("%w: no disc name column found", ErrInvalidImport)
+	}
+
+	get := func(record []string, col string) string {
+		i, ok := colIndex[col]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	file := &ImportFile{Discs: make([]ImportedDisc, 0, len(records)-1)}
+	for i, record := range records[1:] {
+		d := ImportedDisc{
+			Row:      i + 1,
+			Brand:    get(record, "brand"),
+			Name:     get(record, "name"),
+			Plastic:  get(record, "plastic"),
+			ColorHex: normalizeColor(get(record, "color")),
+			Notes:    get(record, "notes"),
+		}
+		if d.Name == "" && d.Brand == "" {
+			continue
+		}
+
+		if w, err := strconv.ParseFloat(strings.TrimSuffix(get(record, "weight"), "g"), 64); err == nil && w > 0 {
+			weight := int(w + 0.5)
+			d.Weight = &weight
+		}
+
+		speed, errS := strconv.ParseFloat(get(record, "speed"), 64)
+		glide, errG := strconv.ParseFloat(get(record, "glide"), 64)
+		turn, errT := strconv.ParseFloat(get(record, "turn"), 64)
+		fade, errF := strconv.ParseFloat(get(record, "fade"), 64)
+		if errS == nil && errG == nil && errT == nil && errF == nil {
+			d.Flight = &disc.FlightNumbers{Speed: speed, Glide: glide, Turn: turn, Fade: fade}
+		}
+
+		file.Discs = append(file.Discs, d)
+	}
+
+	return file, nil
+}
+
+func normalizeHeader(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// normalizeColor keeps hex colors and drops color names, which we can't
+// store.
+func normalizeColor(s string) string {
+	color, err := validation.ValidateHexColor(s, "color")
+	if err != nil {
+		return ""
+	}
+	return color
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+const (
+	MatchExact = "exact"
+	MatchFuzzy = "fuzzy"
+)
+
+type ImportMatch struct {
+	Row           int     `json:"row"`
+	Brand         string  `json:"brand"`
+	Name          string  `json:"name"`
+	CatalogDiscID string  `json:"catalogDiscId"`
+	MatchedBrand  string  `json:"matchedBrand"`
+	MatchedName   string  `json:"matchedName"`
+	MatchType     string  `json:"matchType"`
+	Score         float64 `json:"score"`
+}
+
+type ImportUnmatched struct {
+	Row    int    `json:"row"`
+	Brand  string `json:"brand"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// ImportResult reports every disc in the file as either imported or
+// unmatched. Bag is nil when nothing matched and no bag was created.
+type ImportResult struct {
+	Bag       *Bag              `json:"bag"`
+	Imported  []ImportMatch     `json:"imported"`
+	Unmatched []ImportUnmatched `json:"unmatched"`
+}
@@ -0,0 +1,59 @@
+package catalog
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Categories are the DiscIt category names; custom discs must use one so
+// they filter and group alongside catalog discs.
+var Categories = []string{"Distance Driver", "Hybrid Driver", "Control Driver", "Midrange", "Putter"}
+
+type CustomDiscInput struct {
+	Name     string
+	Brand    string
+	Category string
+	Speed    float64
+	Glide    float64
+	Turn     float64
+	Fade     float64
+}
+
+// CustomDisc is the stored form of a user-created catalog entry.
+type CustomDisc struct {
+	CatalogDisc `bson:",inline"`
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// NewCustomDisc builds a custom entry with the same slugs DiscIt uses, so
+// matching and slug lookups treat it like any other catalog disc.
+func NewCustomDisc(userID string, input CustomDiscInput, now time.Time) *CustomDisc {
+	d := &CustomDisc{
+		CatalogDisc: CatalogDisc{
+			ID:          bson.NewObjectID(),
+			Custom:      true,
+			OwnerUserID: userID,
+		},
+		CreatedAt: now,
+	}
+	d.Apply(input, now)
+	return d
+}
+
+// Apply overwrites the user-editable fields.
+func (d *CustomDisc) Apply(input CustomDiscInput, now time.Time) {
+	d.Name = input.Name
+	d.Brand = input.Brand
+	d.Category = input.Category
+	d.Speed = input.Speed
+	d.Glide = input.Glide
+	d.Turn = input.Turn
+	d.Fade = input.Fade
+	d.NameSlug = Slugify(input.Name)
+	d.BrandSlug = Slugify(input.Brand)
+	d.CategorySlug = Slugify(input.Category)
+	d.UpdatedAt = now
+}
@@ -1,6 +1,10 @@
 package catalog
 
-import "go.mongodb.org/mongo-driver/v2/bson"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
 
 // Master disc catalog downloaded from DiscIt API
 type CatalogDisc struct {
@@ -25,4 +29,26 @@ type CatalogDisc struct {
 	StabilitySlug string `bson:"stability_slug" json:"stability_slug"`
 	Color         string `bson:"color" json:"color"`
 	BgColor       string `bson:"background_color" json:"background_color"`
+
+	// Set by the catalog sync when a mold disappears from DiscIt. The entry
+	// is kept so discs that reference it keep resolving.
+	Discontinued   bool       `bson:"discontinued,omitempty" json:"discontinued,omitempty"`
+	DiscontinuedAt *time.Time `bson:"discontinued_at,omitempty" json:"discontinued_at,omitempty"`
+
+	// Custom discs are user-created entries for molds DiscIt doesn't list
+	// (prototypes, first runs, discontinued molds). They live in their own
+	// collection and are only visible to their owner.
+	Custom      bool   `bson:"custom,omitempty" json:"custom"`
+	OwnerUserID string `bson:"owner_user_id,omitempty" json:"owner_user_id,omitempty"`
+}
+
+type Flight struct {
+	Speed float64 `bson:"speed" json:"speed"`
+	Glide float64 `bson:"glide" json:"glide"`
+	Turn  float64 `bson:"turn" json:"turn"`
+	Fade  float64 `bson:"fade" json:"fade"`
+}
+
+func (d *CatalogDisc) Flight() Flight {
+	return Flight{Speed: d.Speed, Glide: d.Glide, Turn: d.Turn, Fade: d.Fade}
 }
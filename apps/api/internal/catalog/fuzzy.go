@@ -0,0 +1,197 @@
+package catalog
+
+import (
+	"sort"
+	"strings"
+)
+
+// MinSearchScore is the lowest fuzzy score a search result may have.
+const MinSearchScore = 0.6
+
+// ScoredDisc is a search result with how well it matched, from 0 to 1.
+type ScoredDisc struct {
+	*CatalogDisc
+	Score float64 `json:"score"`
+}
+
+// FuzzyIndex is an in-memory trigram index over catalog names for
+// typo-tolerant lookups. It is immutable once built; rebuild it to pick up
+// catalog changes.
+type FuzzyIndex struct {
+	discs  []*CatalogDisc
+	keys   []string // normalized names, parallel to discs
+	fulls  []string // normalized "brand name", parallel to discs
+	grams  map[string][]int
+	bySlug map[string]*CatalogDisc
+}
+
+func NewFuzzyIndex(discs []*CatalogDisc) *FuzzyIndex {
+	idx := &FuzzyIndex{
+		discs:  discs,
+		keys:   make([]string, len(discs)),
+		fulls:  make([]string, len(discs)),
+		grams:  make(map[string][]int),
+		bySlug: make(map[string]*CatalogDisc, len(discs)),
+	}
+
+	for i, d := range discs {
+		idx.keys[i] = normalizeName(d.Name)
+		idx.fulls[i] = normalizeName(d.Brand + " " + d.Name)
+		for _, g := range trigrams(idx.keys[i]) {
+			idx.grams[g] = append(idx.grams[g], i)
+		}
+		idx.bySlug[d.BrandSlug+"/"+d.NameSlug] = d
+	}
+
+	return idx
+}
+
+func (idx *FuzzyIndex) Len() int {
+	return len(idx.discs)
+}
+
+// Discs returns every indexed disc. Callers must not modify the slice.
+func (idx *FuzzyIndex) Discs() []*CatalogDisc {
+	return idx.discs
+}
+
+func (idx *FuzzyIndex) FindBySlug(brandSlug, nameSlug string) *CatalogDisc {
+	return idx.bySlug[brandSlug+"/"+nameSlug]
+}
+
+// Candidates returns up to limit discs whose names share the most
+// trigrams with name. Queries too short for trigrams match by prefix.
+func (idx *FuzzyIndex) Candidates(name string, limit int) []*CatalogDisc {
+	key := normalizeName(name)
+	if key == "" {
+		return nil
+	}
+
+	overlap := make(map[int]int)
+	for _, g := range trigrams(key) {
+		for _, i := range idx.grams[g] {
+			overlap[i]++
+		}
+	}
+	if len([]rune(key)) < 3 {
+		for i, k := range idx.keys {
+			if strings.HasPrefix(k, key) {
+				overlap[i] += 3
+			}
+		}
+	}
+
+	ids := make([]int, 0, len(overlap))
+	for i := range overlap {
+		ids = append(ids, i)
+	}
+	sort.Slice(ids, func(a, b int) bool {
+		if overlap[ids[a]] != overlap[ids[b]] {
+			return overlap[ids[a]] > overlap[ids[b]]
+		}
+		return ids[a] < ids[b]
+	})
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	candidates := make([]*CatalogDisc, len(ids))
+	for i, id := range ids {
+		candidates[i] = idx.discs[id]
+	}
+	return candidates
+}
+
+// Search ranks discs by SearchScore, best first, dropping anything below
+// MinSearchScore. Trigram candidates catch typos; brand prefixes and
+// substrings are found by a scan, which is cheap at catalog size.
+func (idx *FuzzyIndex) Search(query string, limit int) []ScoredDisc {
+	candidates := idx.Candidates(query, max(limit*5, 100))
+
+	q := normalizeName(query)
+	if q != "" {
+		seen := make(map[*CatalogDisc]bool, len(candidates))
+		for _, c := range candidates {
+			seen[c] = true
+		}
+		for i, d := range idx.discs {
+			if !seen[d] && (strings.HasPrefix(idx.fulls[i], q) || strings.Contains(idx.keys[i], q)) {
+				candidates = append(candidates, d)
+			}
+		}
+	}
+
+	return RankDiscs(query, candidates, limit)
+}
+
+// RankDiscs scores discs against query and returns the best limit matches
+// at or above MinSearchScore.
+func RankDiscs(query string, discs []*CatalogDisc, limit int) []ScoredDisc {
+	results := []ScoredDisc{}
+	for _, d := range discs {
+		if score := SearchScore(query, d); score >= MinSearchScore {
+			results = append(results, ScoredDisc{CatalogDisc: d, Score: score})
+		}
+	}
+
+	sort.SliceStable(results, func(a, b int) bool {
+		if results[a].Score != results[b].Score {
+			return results[a].Score > results[b].Score
+		}
+		return results[a].Name < results[b].Name
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// SearchScore rates how well a typed query matches a disc: exact names
+// first, then prefixes (people search while typing), substrings, and
+// finally edit-distance similarity so typos still land. Queries that
+// include the brand ("innova destroyer") are compared against both.
+func SearchScore(query string, d *CatalogDisc) float64 {
+	q := normalizeName(query)
+	name := normalizeName(d.Name)
+	full := normalizeName(d.Brand + " " + d.Name)
+	if q == "" || name == "" {
+		return 0
+	}
+
+	switch {
+	case q == name || q == full:
+		return 1
+	case strings.HasPrefix(name, q):
+		return 0.9 + 0.09*float64(len(q))/float64(len(name))
+	case strings.HasPrefix(full, q) || strings.Contains(name, q):
+		return 0.8
+	}
+
+	score := max(Similarity(q, name), Similarity(q, full))
+
+	// A typo in a partially typed name: compare against the same-length
+	// prefix, slightly discounted.
+	if qr, nr := []rune(q), []rune(name); len(qr) >= 3 && len(nr) > len(qr) {
+		score = max(score, 0.9*Similarity(q, string(nr[:len(qr)])))
+	}
+
+	return score
+}
+
+// normalizeName lowercases and keeps letters and digits, with single
+// spaces between words.
+func normalizeName(s string) string {
+	return strings.ReplaceAll(Slugify(s), "-", " ")
+}
+
+// trigrams of each word, padded so word starts and ends count.
+func trigrams(s string) []string {
+	var grams []string
+	for _, word := range strings.Fields(s) {
+		r := []rune("$" + word + "$")
+		for i := 0; i+3 <= len(r); i++ {
+			grams = append(grams, string(r[i:i+3]))
+		}
+	}
+	return grams
+}
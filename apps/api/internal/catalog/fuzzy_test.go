@@ -0,0 +1,91 @@
+package catalog
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSearchScore(t *testing.T) {
+	destroyer := &CatalogDisc{Brand: "Innova", Name: "Destroyer"}
+
+	tests := []struct {
+		name     string
+		query    string
+		min, max float64
+	}{
+		{"exact name", "Destroyer", 1, 1},
+		{"brand and name", "innova destroyer", 1, 1},
+		{"case and punctuation", "DESTROYER!", 1, 1},
+		{"name prefix", "dest", 0.94, 0.94},
+		{"brand prefix", "innova d", 0.8, 0.8},
+		{"substring", "troy", 0.8, 0.8},
+		{"missing letter", "destroyr", 8.0 / 9, 8.0 / 9},
+		{"swapped letters", "detsroyer", 8.0 / 9, 8.0 / 9},
+		{"typo while typing", "destri", 0.75, 0.75},
+		{"unrelated", "wraith", 0, MinSearchScore - 0.01},
+		{"empty", "  ", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SearchScore(tt.query, destroyer)
+			if got < tt.min-1e-9 || got > tt.max+1e-9 {
+				t.Errorf("SearchScore(%q) = %g, want %g..%g", tt.query, got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestFuzzyIndexSearch(t *testing.T) {
+	idx := NewFuzzyIndex([]*CatalogDisc{
+		{Brand: "Innova", Name: "Destroyer"},
+		{Brand: "Innova", Name: "Wraith"},
+		{Brand: "Discraft", Name: "Buzzz"},
+		{Brand: "Discraft", Name: "Zone"},
+	})
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string
+	}{
+		{"typo", "destroyr", 10, []string{"Destroyer"}},
+		{"transposition", "wriath", 10, []string{"Wraith"}},
+		{"brand only", "innova", 10, []string{"Destroyer", "Wraith"}},
+		{"limit", "innova", 1, []string{"Destroyer"}},
+		{"too short for trigrams", "zo", 10, []string{"Zone"}},
+		{"partial name", "buz", 10, []string{"Buzzz"}},
+		{"nothing close", "xyz", 10, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, r := range idx.Search(tt.query, tt.limit) {
+				got = append(got, r.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+// Results come best first and drop anything below MinSearchScore.
+func TestRankDiscsOrder(t *testing.T) {
+	discs := []*CatalogDisc{
+		{Brand: "Innova", Name: "Destroyer"},
+		{Brand: "Discmania", Name: "DD3"},
+		{Brand: "Innova", Name: "Dart"},
+		{Brand: "Innova", Name: "Dx Destroyer"},
+	}
+
+	var got []string
+	for _, r := range RankDiscs("destroyer", discs, 0) {
+		got = append(got, r.Name)
+	}
+	if want := []string{"Destroyer", "Dx Destroyer"}; !slices.Equal(got, want) {
+		t.Errorf("RankDiscs = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,71 @@
+package catalog
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Slugify produces slugs in the same shape DiscIt uses, e.g.
+// "Latitude 64" -> "latitude-64".
+func Slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// Similarity scores two names from 0 (nothing alike) to 1 (same slug),
+// ignoring case, spacing and punctuation.
+func Similarity(a, b string) float64 {
+	a = strings.ReplaceAll(Slugify(a), "-", "")
+	b = strings.ReplaceAll(Slugify(b), "-", "")
+	if a == b {
+		return 1
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(editDistance(ra, rb))/float64(longest)
+}
+
+// editDistance is the optimal string alignment distance: Levenshtein plus
+// adjacent transpositions, the most common typo in mold names.
+func editDistance(a, b []rune) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(a)][len(b)]
+}
@@ -0,0 +1,204 @@
+package catalog
+
+import (
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Stabilities are the DiscIt stability labels.
+var Stabilities = []string{"Very Overstable", "Overstable", "Stable", "Understable", "Very Understable"}
+
+// OverrideFieldNames are the catalog fields an override can correct.
+var OverrideFieldNames = []string{"category", "stability", "speed", "glide", "turn", "fade", "pic"}
+
+// OverrideFields are corrections to DiscIt data. Nil fields keep the
+// DiscIt value.
+type OverrideFields struct {
+	Category  *string  `bson:"category,omitempty" json:"category,omitempty"`
+	Stability *string  `bson:"stability,omitempty" json:"stability,omitempty"`
+	Speed     *float64 `bson:"speed,omitempty" json:"speed,omitempty"`
+	Glide     *float64 `bson:"glide,omitempty" json:"glide,omitempty"`
+	Turn      *float64 `bson:"turn,omitempty" json:"turn,omitempty"`
+	Fade      *float64 `bson:"fade,omitempty" json:"fade,omitempty"`
+	Pic       *string  `bson:"pic,omitempty" json:"pic,omitempty"`
+}
+
+// OverrideChange records one field an edit changed. Before is the value
+// the disc had at the time, whether from DiscIt or an earlier override.
+// Cleared marks an override being removed, with After the DiscIt value it
+// went back to.
+type OverrideChange struct {
+	Field   string `bson:"field" json:"field"`
+	Before  any    `bson:"before" json:"before"`
+	After   any    `bson:"after" json:"after"`
+	Cleared bool   `bson:"cleared,omitempty" json:"cleared,omitempty"`
+}
+
+// OverrideAudit is one PATCH or clear against a catalog disc.
+type OverrideAudit struct {
+	UserID  string           `bson:"userId" json:"userId"`
+	At      time.Time        `bson:"at" json:"at"`
+	Changes []OverrideChange `bson:"changes" json:"changes"`
+}
+
+// Override is the stored correction layer for one DiscIt mold, keyed by
+// catalog ID (which a sync never changes). The catalog sync applies it on
+// top of the incoming DiscIt data, so corrections survive reseeding.
+// Original holds DiscIt's values for the overridden fields so clearing one
+// can put the DiscIt value back; the sync keeps it current.
+type Override struct {
+	ID        bson.ObjectID   `bson:"_id,omitempty" json:"_id"`
+	CatalogID bson.ObjectID   `bson:"catalog_id" json:"catalog_id"`
+	Fields    OverrideFields  `bson:"fields" json:"fields"`
+	Original  OverrideFields  `bson:"original" json:"original"`
+	Audit     []OverrideAudit `bson:"audit" json:"audit"`
+	CreatedAt time.Time       `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time       `bson:"updated_at" json:"updated_at"`
+}
+
+// Apply writes the overridden fields onto d, keeping the slugs in step.
+func (f OverrideFields) Apply(d *CatalogDisc) {
+	if f.Category != nil {
+		d.Category = *f.Category
+		d.CategorySlug = Slugify(*f.Category)
+	}
+	if f.Stability != nil {
+		d.Stability = *f.Stability
+		d.StabilitySlug = Slugify(*f.Stability)
+	}
+	if f.Speed != nil {
+		d.Speed = *f.Speed
+	}
+	if f.Glide != nil {
+		d.Glide = *f.Glide
+	}
+	if f.Turn != nil {
+		d.Turn = *f.Turn
+	}
+	if f.Fade != nil {
+		d.Fade = *f.Fade
+	}
+	if f.Pic != nil {
+		d.Pic = *f.Pic
+	}
+}
+
+// Merge layers patch over the override and lists what changes on d, which
+// must be the disc as it currently stands. Fields set to their current
+// value are not recorded. The first time a field is overridden its current
+// value is kept in Original.
+func (o *Override) Merge(patch OverrideFields, d *CatalogDisc) []OverrideChange {
+	var changes []OverrideChange
+	record := func(c *OverrideChange) {
+		if c != nil {
+			changes = append(changes, *c)
+		}
+	}
+
+	record(mergeField("category", &o.Fields.Category, &o.Original.Category, patch.Category, d.Category))
+	record(mergeField("stability", &o.Fields.Stability, &o.Original.Stability, patch.Stability, d.Stability))
+	record(mergeField("speed", &o.Fields.Speed, &o.Original.Speed, patch.Speed, d.Speed))
+	record(mergeField("glide", &o.Fields.Glide, &o.Original.Glide, patch.Glide, d.Glide))
+	record(mergeField("turn", &o.Fields.Turn, &o.Original.Turn, patch.Turn, d.Turn))
+	record(mergeField("fade", &o.Fields.Fade, &o.Original.Fade, patch.Fade, d.Fade))
+	record(mergeField("pic", &o.Fields.Pic, &o.Original.Pic, patch.Pic, d.Pic))
+
+	return changes
+}
+
+func mergeField[T comparable](field string, dst, original **T, src *T, current T) *OverrideChange {
+	if src == nil {
+		return nil
+	}
+	if *dst == nil {
+		*original = &current
+	}
+	*dst = src
+	if *src == current {
+		return nil
+	}
+	return &OverrideChange{Field: field, Before: current, After: *src}
+}
+
+// Clear drops the named overrides, or all of them when names is empty,
+// and lists what changes on d, which must be the disc as it currently
+// stands. It returns the DiscIt values to write back for the cleared
+// fields.
+func (o *Override) Clear(names []string, d *CatalogDisc) (OverrideFields, []OverrideChange) {
+	var restored OverrideFields
+	var changes []OverrideChange
+	record := func(c *OverrideChange) {
+		if c != nil {
+			changes = append(changes, *c)
+		}
+	}
+	named := func(field string) bool {
+		return len(names) == 0 || slices.Contains(names, field)
+	}
+
+	if named("category") {
+		record(clearField("category", &o.Fields.Category, &o.Original.Category, &restored.Category, d.Category))
+	}
+	if named("stability") {
+		record(clearField("stability", &o.Fields.Stability, &o.Original.Stability, &restored.Stability, d.Stability))
+	}
+	if named("speed") {
+		record(clearField("speed", &o.Fields.Speed, &o.Original.Speed, &restored.Speed, d.Speed))
+	}
+	if named("glide") {
+		record(clearField("glide", &o.Fields.Glide, &o.Original.Glide, &restored.Glide, d.Glide))
+	}
+	if named("turn") {
+		record(clearField("turn", &o.Fields.Turn, &o.Original.Turn, &restored.Turn, d.Turn))
+	}
+	if named("fade") {
+		record(clearField("fade", &o.Fields.Fade, &o.Original.Fade, &restored.Fade, d.Fade))
+	}
+	if named("pic") {
+		record(clearField("pic", &o.Fields.Pic, &o.Original.Pic, &restored.Pic, d.Pic))
+	}
+
+	return restored, changes
+}
+
+func clearField[T comparable](field string, dst, original, restored **T, current T) *OverrideChange {
+	if *dst == nil {
+		return nil
+	}
+	*dst = nil
+
+	after := current
+	if *original != nil {
+		after = **original
+		*restored = *original
+	}
+	*original = nil
+	return &OverrideChange{Field: field, Before: current, After: after, Cleared: true}
+}
+
+// TrackOriginal records d's values, fresh from DiscIt, as the originals of
+// every overridden field. It reports whether any original changed.
+func (o *Override) TrackOriginal(d *CatalogDisc) bool {
+	changed := false
+	track := func(c bool) { changed = changed || c }
+
+	track(trackField(o.Fields.Category, &o.Original.Category, d.Category))
+	track(trackField(o.Fields.Stability, &o.Original.Stability, d.Stability))
+	track(trackField(o.Fields.Speed, &o.Original.Speed, d.Speed))
+	track(trackField(o.Fields.Glide, &o.Original.Glide, d.Glide))
+	track(trackField(o.Fields.Turn, &o.Original.Turn, d.Turn))
+	track(trackField(o.Fields.Fade, &o.Original.Fade, d.Fade))
+	track(trackField(o.Fields.Pic, &o.Original.Pic, d.Pic))
+
+	return changed
+}
+
+func trackField[T comparable](set *T, original **T, value T) bool {
+	if set == nil || (*original != nil && **original == value) {
+		return false
+	}
+	*original = &value
+	return true
+}
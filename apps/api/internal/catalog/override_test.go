@@ -0,0 +1,54 @@
+package catalog
+
+import "testing"
+
+func TestOverrideClearRestoresDiscIt(t *testing.T) {
+	d := &CatalogDisc{Speed: 12, Turn: -1, Stability: "Stable", StabilitySlug: "stable"}
+
+	var o Override
+	speed, turn := 11.0, -2.0
+	o.Merge(OverrideFields{Speed: &speed, Turn: &turn}, d)
+	o.Fields.Apply(d)
+
+	// A later edit to the same field must not replace the DiscIt original.
+	faster := 13.0
+	o.Merge(OverrideFields{Speed: &faster}, d)
+	o.Fields.Apply(d)
+
+	restored, changes := o.Clear([]string{"speed"}, d)
+	if o.Fields.Speed != nil || o.Fields.Turn == nil {
+		t.Fatalf("Fields = %+v, want only turn left", o.Fields)
+	}
+	if restored.Speed == nil || *restored.Speed != 12 {
+		t.Fatalf("restored speed = %v, want 12", restored.Speed)
+	}
+	if len(changes) != 1 || changes[0] != (OverrideChange{Field: "speed", Before: 13.0, After: 12.0, Cleared: true}) {
+		t.Errorf("changes = %+v", changes)
+	}
+
+	restored.Apply(d)
+	if d.Speed != 12 || d.Turn != -2 {
+		t.Errorf("disc flight = %g/%g, want 12/-2", d.Speed, d.Turn)
+	}
+
+	// Clearing everything only touches fields that are still overridden.
+	restored, changes = o.Clear(nil, d)
+	if len(changes) != 1 || changes[0].Field != "turn" || restored.Turn == nil || *restored.Turn != -1 {
+		t.Errorf("clear all: restored = %+v, changes = %+v", restored, changes)
+	}
+}
+
+func TestOverrideTrackOriginal(t *testing.T) {
+	speed, original := 11.0, 12.0
+	o := Override{Fields: OverrideFields{Speed: &speed}, Original: OverrideFields{Speed: &original}}
+
+	if o.TrackOriginal(&CatalogDisc{Speed: 12, Glide: 5}) {
+		t.Error("unchanged DiscIt data reported as a change")
+	}
+	if !o.TrackOriginal(&CatalogDisc{Speed: 13, Glide: 5}) || *o.Original.Speed != 13 {
+		t.Errorf("Original.Speed = %v, want 13", o.Original.Speed)
+	}
+	if o.Original.Glide != nil {
+		t.Error("original recorded for a field that isn't overridden")
+	}
+}
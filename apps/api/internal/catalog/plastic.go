@@ -0,0 +1,98 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Durability tiers, from fastest to slowest to beat in.
+const (
+	DurabilityBase    = "base"
+	DurabilityMid     = "mid"
+	DurabilityPremium = "premium"
+)
+
+// Grip levels.
+const (
+	GripLow    = "low"
+	GripMedium = "medium"
+	GripHigh   = "high"
+)
+
+var (
+	DurabilityTiers = []string{DurabilityBase, DurabilityMid, DurabilityPremium}
+	GripLevels      = []string{GripLow, GripMedium, GripHigh}
+)
+
+var ErrInvalidPlastic = errors.New("invalid plastic")
+
+// Plastic is one blend in a manufacturer's lineup. Brands that share a
+// factory (MVP, Axiom and Streamline) list the same blends separately, so
+// lookups stay per brand.
+type Plastic struct {
+	Brand      string   `bson:"brand" json:"brand"`
+	BrandSlug  string   `bson:"brand_slug" json:"brand_slug"`
+	Name       string   `bson:"name" json:"name"`
+	Slug       string   `bson:"slug" json:"slug"`
+	Aliases    []string `bson:"aliases,omitempty" json:"aliases,omitempty"`
+	Durability string   `bson:"durability" json:"durability"`
+	Grip       string   `bson:"grip" json:"grip"`
+	// How much more overstable (positive) or understable (negative) a mold
+	// typically flies in this blend than its stock numbers, in the same
+	// turn + fade units as catalog stability.
+	StabilityModifier float64 `bson:"stability_modifier" json:"stability_modifier"`
+}
+
+// PlasticOption is a blend offered for a catalog disc, with the flight
+// numbers the mold would typically have in it.
+type PlasticOption struct {
+	Plastic
+	SuggestedFlight Flight `json:"suggestedFlight"`
+}
+
+// Normalize fills in slugs and checks the blend is complete.
+func (p *Plastic) Normalize() error {
+	p.BrandSlug = Slugify(p.Brand)
+	p.Slug = Slugify(p.Name)
+
+	switch {
+	case p.BrandSlug == "" || p.Slug == "":
+		return fmt.Errorf("%w: brand and name are required", ErrInvalidPlastic)
+	case !slices.Contains(DurabilityTiers, p.Durability):
+		return fmt.Errorf("%w: %s: unknown durability %q", ErrInvalidPlastic, p.Name, p.Durability)
+	case !slices.Contains(GripLevels, p.Grip):
+		return fmt.Errorf("%w: %s: unknown grip %q", ErrInvalidPlastic, p.Name, p.Grip)
+	case math.Abs(p.StabilityModifier) > 2:
+		return fmt.Errorf("%w: %s: stability modifier must be between -2 and 2", ErrInvalidPlastic, p.Name)
+	}
+	return nil
+}
+
+// Matches reports whether a typed plastic name refers to this blend,
+// ignoring case and punctuation ("Champion", "champ", "G-Star").
+func (p *Plastic) Matches(name string) bool {
+	slug := Slugify(name)
+	if slug == p.Slug {
+		return true
+	}
+	for _, a := range p.Aliases {
+		if Slugify(a) == slug {
+			return true
+		}
+	}
+	return false
+}
+
+// AdjustFlight shifts stock flight numbers by the blend's stability
+// modifier, split between turn and fade and rounded to half steps.
+func (p *Plastic) AdjustFlight(stock Flight) Flight {
+	half := p.StabilityModifier / 2
+	return Flight{
+		Speed: stock.Speed,
+		Glide: stock.Glide,
+		Turn:  math.Round((stock.Turn+half)*2) / 2,
+		Fade:  math.Max(0, math.Round((stock.Fade+half)*2)/2),
+	}
+}
@@ -0,0 +1,70 @@
+package catalog
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// How a search matched its text query. Text mode uses the Mongo text index
+// on name and brand; regex mode is the fallback for partial words.
+const (
+	SearchModeNone  = ""
+	SearchModeText  = "text"
+	SearchModeRegex = "regex"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type FacetCount struct {
+	Value string `bson:"_id" json:"value"`
+	Label string `bson:"label" json:"label"`
+	Count int    `bson:"count" json:"count"`
+}
+
+type SearchFacets struct {
+	Brands     []FacetCount `json:"brands"`
+	Categories []FacetCount `json:"categories"`
+}
+
+// SearchPage is one page of results. Facet counts cover every match, not
+// just this page; each facet ignores its own filter so the client can show
+// the other options.
+type SearchPage struct {
+	Discs      []*CatalogDisc `json:"discs"`
+	Total      int            `json:"total"`
+	Facets     SearchFacets   `json:"facets"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// SearchCursor marks the last result of a page in the (score desc, name,
+// _id) ordering, plus the mode so the next page matches the same way.
+type SearchCursor struct {
+	Mode  string        `json:"m,omitempty"`
+	Score float64       `json:"s"`
+	Name  string        `json:"n"`
+	ID    bson.ObjectID `json:"i"`
+}
+
+func (c SearchCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeSearchCursor(s string) (*SearchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c SearchCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+	if c.Mode != SearchModeNone && c.Mode != SearchModeText && c.Mode != SearchModeRegex {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
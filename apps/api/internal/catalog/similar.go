@@ -0,0 +1,87 @@
+package catalog
+
+import (
+	"math"
+	"sort"
+)
+
+// FlightWeights scale each flight number's contribution to FlightDistance.
+// Speed and glide matter less than turn and fade: a 9 and a 10 speed
+// driver with the same finish fly alike, while a point of fade does not.
+type FlightWeights struct {
+	Speed float64
+	Glide float64
+	Turn  float64
+	Fade  float64
+}
+
+var DefaultFlightWeights = FlightWeights{Speed: 1, Glide: 0.5, Turn: 1.5, Fade: 1.5}
+
+// FlightDistance is the weighted Euclidean distance between two sets of
+// flight numbers. Zero means identical numbers.
+func FlightDistance(a, b Flight, w FlightWeights) float64 {
+	ds := a.Speed - b.Speed
+	dg := a.Glide - b.Glide
+	dt := a.Turn - b.Turn
+	df := a.Fade - b.Fade
+	return math.Sqrt(w.Speed*ds*ds + w.Glide*dg*dg + w.Turn*dt*dt + w.Fade*df*df)
+}
+
+type SimilarOptions struct {
+	BrandSlugs     []string // only these brands, if set
+	DifferentBrand bool     // exclude the reference disc's brand
+	CategorySlugs  []string // only these categories, if set
+	Limit          int
+}
+
+// SimilarDisc is a mold near the reference disc, closest first.
+type SimilarDisc struct {
+	*CatalogDisc
+	Distance float64 `json:"distance"`
+}
+
+// RankSimilar returns the discs closest to ref in flight-number space.
+// The reference itself, other entries for the same mold and discontinued
+// molds are skipped.
+func RankSimilar(ref *CatalogDisc, discs []*CatalogDisc, opts SimilarOptions) []SimilarDisc {
+	brands := toSet(opts.BrandSlugs)
+	categories := toSet(opts.CategorySlugs)
+	refFlight := ref.Flight()
+
+	results := []SimilarDisc{}
+	for _, d := range discs {
+		switch {
+		case d.ID == ref.ID,
+			d.BrandSlug == ref.BrandSlug && d.NameSlug == ref.NameSlug,
+			d.Discontinued,
+			opts.DifferentBrand && d.BrandSlug == ref.BrandSlug,
+			len(brands) > 0 && !brands[d.BrandSlug],
+			len(categories) > 0 && !categories[d.CategorySlug]:
+			continue
+		}
+
+		results = append(results, SimilarDisc{
+			CatalogDisc: d,
+			Distance:    math.Round(FlightDistance(refFlight, d.Flight(), DefaultFlightWeights)*1000) / 1000,
+		})
+	}
+
+	sort.SliceStable(results, func(a, b int) bool {
+		if results[a].Distance != results[b].Distance {
+			return results[a].Distance < results[b].Distance
+		}
+		return results[a].Name < results[b].Name
+	})
+	if opts.Limit > 0 && len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+	return results
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
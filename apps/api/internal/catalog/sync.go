@@ -0,0 +1,68 @@
+package catalog
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Kinds of change a catalog sync can make to a mold.
+const (
+	ChangeAdded        = "added"
+	ChangeUpdated      = "updated"
+	ChangeDiscontinued = "discontinued"
+	ChangeRestored     = "restored"
+)
+
+// Change is one changelog entry from a catalog sync. Before and After are
+// only set when the flight numbers changed.
+type Change struct {
+	Kind      string        `bson:"kind" json:"kind"`
+	At        time.Time     `bson:"at" json:"at"`
+	CatalogID bson.ObjectID `bson:"catalog_id" json:"catalog_id"`
+	DiscItID  string        `bson:"discit_id" json:"discit_id"`
+	Name      string        `bson:"name" json:"name"`
+	Brand     string        `bson:"brand" json:"brand"`
+	BrandSlug string        `bson:"brand_slug" json:"brand_slug"`
+	Fields    []string      `bson:"fields,omitempty" json:"fields,omitempty"`
+	Before    *Flight       `bson:"before,omitempty" json:"before,omitempty"`
+	After     *Flight       `bson:"after,omitempty" json:"after,omitempty"`
+}
+
+type SyncResult struct {
+	Added        int      `json:"added"`
+	Updated      int      `json:"updated"`
+	Unchanged    int      `json:"unchanged"`
+	Discontinued int      `json:"discontinued"`
+	Restored     int      `json:"restored"`
+	Changes      []Change `json:"changes"`
+}
+
+// HistoryChanges returns the changes worth keeping in the catalog history:
+// newly appeared molds and flight-number changes.
+func (r *SyncResult) HistoryChanges() []Change {
+	var changes []Change
+	for _, c := range r.Changes {
+		if c.Kind == ChangeAdded || (c.Before != nil && c.After != nil) {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// FlightChanges returns the changes that moved a mold's flight numbers.
+func (r *SyncResult) FlightChanges() []Change {
+	var changes []Change
+	for _, c := range r.Changes {
+		if c.Before != nil && c.After != nil {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// NewRelease is a mold that appeared in the catalog, as it stands now.
+type NewRelease struct {
+	*CatalogDisc
+	AddedAt time.Time `json:"added_at"`
+}
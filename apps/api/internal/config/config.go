@@ -23,6 +23,25 @@ type Config struct {
 
 	// Seeding
 	ForceReseed bool
+
+	// Catalog source: "http", "file" or "embedded"
+	CatalogSource           string
+	CatalogSourceURL        string
+	CatalogSourceFile       string
+	CatalogEmbeddedFallback bool
+
+	// Plastics registry JSON; the bundled registry is used when empty
+	PlasticsFile string
+
+	// Photo storage: "local" or "s3"
+	BlobStore      string
+	BlobLocalDir   string
+	S3Endpoint     string
+	S3Region       string
+	S3Bucket       string
+	S3AccessKeyID  string
+	S3SecretKey    string
+	S3UsePathStyle bool
 }
 
 func Load() (*Config, error) {
@@ -43,6 +62,22 @@ func Load() (*Config, error) {
 		CookieDomain:  getEnv("COOKIE_DOMAIN", ""),
 
 		ForceReseed: getEnv("FORCE_RESEED", "") == "true",
+
+		CatalogSource:           getEnv("CATALOG_SOURCE", "http"),
+		CatalogSourceURL:        getEnv("CATALOG_SOURCE_URL", "https://discit-api.fly.dev/disc"),
+		CatalogSourceFile:       getEnv("CATALOG_SOURCE_FILE", ""),
+		CatalogEmbeddedFallback: getEnv("CATALOG_EMBEDDED_FALLBACK", "true") == "true",
+
+		PlasticsFile: getEnv("PLASTICS_FILE", ""),
+
+		BlobStore:      getEnv("BLOB_STORE", "local"),
+		BlobLocalDir:   getEnv("BLOB_LOCAL_DIR", "./data/blobs"),
+		S3Endpoint:     getEnv("S3_ENDPOINT", ""),
+		S3Region:       getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:       getEnv("S3_BUCKET", ""),
+		S3AccessKeyID:  getEnv("S3_ACCESS_KEY_ID", ""),
+		S3SecretKey:    getEnv("S3_SECRET_ACCESS_KEY", ""),
+		S3UsePathStyle: getEnv("S3_USE_PATH_STYLE", "true") == "true",
 	}
 
 	if cfg.MongoURI == "" {
@@ -54,6 +89,15 @@ func Load() (*Config, error) {
 	if cfg.AuthJWTSecret == "" {
 		return nil, fmt.Errorf("AUTH_JWT_SECRET is required")
 	}
+	switch cfg.BlobStore {
+	case "local":
+	case "s3":
+		if cfg.S3Endpoint == "" || cfg.S3Bucket == "" {
+			return nil, fmt.Errorf("S3_ENDPOINT and S3_BUCKET are required when BLOB_STORE=s3")
+		}
+	default:
+		return nil, fmt.Errorf("BLOB_STORE must be local or s3")
+	}
 
 	return cfg, nil
 }
@@ -2,10 +2,12 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
@@ -39,6 +41,24 @@ func NewMongoDB(uri, dbName string) (*MongoDB, error) {
 	}, nil
 }
 
+// RequireReplicaSet fails when the server is a standalone mongod. Transactions
+// need a replica set (or a mongos in front of one), and without this check the
+// first bag delete or clone would be the one to find out.
+func (m *MongoDB) RequireReplicaSet(ctx context.Context) error {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := m.Client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return fmt.Errorf("failed to check MongoDB topology: %w", err)
+	}
+
+	if hello.SetName == "" && hello.Msg != "isdbgrid" {
+		return errors.New("MongoDB is not a replica set: transactions need one (start mongod with --replSet and run rs.initiate(), see docs/DOCKER.md)")
+	}
+	return nil
+}
+
 func (m *MongoDB) Close(ctx context.Context) error {
 	return m.Client.Disconnect(ctx)
 }
@@ -16,7 +16,7 @@ type FlightNumbers struct {
 type Disc struct {
 	ID            bson.ObjectID `bson:"_id,omitempty" json:"_id"`
 	UserID        string        `bson:"userId" json:"userId"`
-	BagID         bson.ObjectID `bson:"bagId" json:"bagId"`
+	BagID         bson.ObjectID `bson:"bagId,omitempty" json:"bagId"`
 	CatalogDiscID string        `bson:"catalogDiscId" json:"catalogDiscId"`
 
 	Name         string `bson:"name" json:"name"`
@@ -26,13 +26,21 @@ type Disc struct {
 	BrandSlug    string `bson:"brandSlug" json:"brandSlug"`
 	CategorySlug string `bson:"categorySlug" json:"categorySlug"`
 
-	Plastic  string `bson:"plastic,omitempty" json:"plastic,omitempty"`
-	Weight   *int   `bson:"weight,omitempty" json:"weight,omitempty"`
-	ColorHex string `bson:"colorHex,omitempty" json:"colorHex,omitempty"`
-	Notes    string `bson:"notes,omitempty" json:"notes,omitempty"`
+	Plastic  string  `bson:"plastic,omitempty" json:"plastic,omitempty"`
+	Weight   *int    `bson:"weight,omitempty" json:"weight,omitempty"`
+	ColorHex string  `bson:"colorHex,omitempty" json:"colorHex,omitempty"`
+	Notes    string  `bson:"notes,omitempty" json:"notes,omitempty"`
+	Photos   []Photo `bson:"photos,omitempty" json:"photos,omitempty"`
 
 	StockFlight    FlightNumbers  `bson:"stockFlight" json:"stockFlight"`
 	AdjustedFlight *FlightNumbers `bson:"adjustedFlight,omitempty" json:"adjustedFlight,omitempty"`
+	FlightHistory  []FlightChange `bson:"flightHistory,omitempty" json:"flightHistory,omitempty"`
+
+	Status        string         `bson:"status" json:"status"`
+	StatusHistory []StatusChange `bson:"statusHistory,omitempty" json:"statusHistory,omitempty"`
+	LostCourse    string         `bson:"lostCourse,omitempty" json:"lostCourse,omitempty"`
+	LostAt        *time.Time     `bson:"lostAt,omitempty" json:"lostAt,omitempty"`
+	RecoveredAt   *time.Time     `bson:"recoveredAt,omitempty" json:"recoveredAt,omitempty"`
 
 	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
 	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
@@ -47,11 +55,28 @@ type CreateDiscInput struct {
 	Plastic        string
 }
 
+// A nil AdjustedFlight leaves the flight numbers alone; ResetFlight puts the
+// disc back on its stock numbers.
 type UpdateDiscInput struct {
 	BagID          *bson.ObjectID
 	AdjustedFlight *FlightNumbers
+	ResetFlight    bool
+	FlightReason   string
 	Notes          string
 	ColorHex       string
 	Weight         *int
 	Plastic        string
 }
+
+// EffectiveFlight returns the user's adjusted numbers when set, otherwise the stock numbers.
+func (d *Disc) EffectiveFlight() FlightNumbers {
+	if d.AdjustedFlight != nil {
+		return *d.AdjustedFlight
+	}
+	return d.StockFlight
+}
+
+// Stability is turn + fade, the same measure the catalog suggest endpoint uses.
+func (f FlightNumbers) Stability() float64 {
+	return f.Turn + f.Fade
+}
@@ -0,0 +1,167 @@
+package disc
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// FlightChange records the adjusted numbers a disc had from ChangedAt on.
+// A nil Flight means the disc was set back to its stock numbers.
+type FlightChange struct {
+	Flight    *FlightNumbers `bson:"flight,omitempty" json:"flight,omitempty"`
+	ChangedAt time.Time      `bson:"changedAt" json:"changedAt"`
+	Reason    string         `bson:"reason,omitempty" json:"reason,omitempty"`
+}
+
+const (
+	SeasoningNew       = "new"
+	SeasoningSeasoning = "seasoning"
+	SeasoningSeasoned  = "seasoned"
+	SeasoningBeatIn    = "beat_in"
+)
+
+// Seasoning is a rough wear estimate. Nothing ties a round to the discs
+// thrown in it, so every round played while the disc was in a bag counts.
+type Seasoning struct {
+	RoundsInBag  int     `json:"roundsInBag"`
+	TaggedThrows int     `json:"taggedThrows"`
+	WearScore    float64 `json:"wearScore"`
+	Level        string  `json:"level"`
+	// How much turn and fade wear alone would be expected to have taken off.
+	EstimatedDrift FlightNumbers `json:"estimatedDrift"`
+}
+
+type History struct {
+	DiscID        bson.ObjectID  `json:"discId"`
+	StockFlight   FlightNumbers  `json:"stockFlight"`
+	CurrentFlight FlightNumbers  `json:"currentFlight"`
+	Drift         FlightNumbers  `json:"drift"`
+	FlightChanges []FlightChange `json:"flightChanges"`
+	StatusHistory []StatusChange `json:"statusHistory"`
+	Seasoning     Seasoning      `json:"seasoning"`
+}
+
+// Throws per round, for weighing tagged TechDisc throws against rounds.
+const throwsPerRound = 20.0
+
+// Base plastics beat in roughly twice as fast as premium blends.
+var basePlastics = []string{"dx", "pro-d", "retro", "baseline", "base", "classic", "x-out", "electron"}
+
+func NewHistory(d *Disc, roundsInBag, taggedThrows int) *History {
+	current := d.EffectiveFlight()
+
+	changes := d.FlightHistory
+	if changes == nil {
+		changes = []FlightChange{}
+	}
+	statuses := d.StatusHistory
+	if statuses == nil {
+		statuses = []StatusChange{}
+	}
+
+	return &History{
+		DiscID:        d.ID,
+		StockFlight:   d.StockFlight,
+		CurrentFlight: current,
+		Drift: FlightNumbers{
+			Speed: current.Speed - d.StockFlight.Speed,
+			Glide: current.Glide - d.StockFlight.Glide,
+			Turn:  current.Turn - d.StockFlight.Turn,
+			Fade:  current.Fade - d.StockFlight.Fade,
+		},
+		FlightChanges: changes,
+		StatusHistory: statuses,
+		Seasoning:     EstimateSeasoning(d.Plastic, roundsInBag, taggedThrows),
+	}
+}
+
+func EstimateSeasoning(plastic string, roundsInBag, taggedThrows int) Seasoning {
+	wear := float64(roundsInBag) + float64(taggedThrows)/throwsPerRound
+
+	lower := strings.ToLower(plastic)
+	for _, p := range basePlastics {
+		if lower == p || strings.HasPrefix(lower, p+" ") {
+			wear *= 2
+			break
+		}
+	}
+
+	level := SeasoningNew
+	switch {
+	case wear >= 100:
+		level = SeasoningBeatIn
+	case wear >= 40:
+		level = SeasoningSeasoned
+	case wear >= 10:
+		level = SeasoningSeasoning
+	}
+
+	return Seasoning{
+		RoundsInBag:  roundsInBag,
+		TaggedThrows: taggedThrows,
+		WearScore:    math.Round(wear*10) / 10,
+		Level:        level,
+		EstimatedDrift: FlightNumbers{
+			Turn: 0 - halfStep(math.Min(wear/50, 2)),
+			Fade: 0 - halfStep(math.Min(wear/100, 1)),
+		},
+	}
+}
+
+// CountInBag counts the times that fall inside a period the disc spent in a
+// bag, according to its status history.
+func CountInBag(d *Disc, times []time.Time, now time.Time) int {
+	type interval struct{ start, end time.Time }
+
+	var intervals []interval
+	if len(d.StatusHistory) == 0 {
+		if d.Status == StatusInBag || d.Status == "" {
+			intervals = append(intervals, interval{d.CreatedAt, now})
+		}
+	} else {
+		var open *time.Time
+		for _, c := range d.StatusHistory {
+			switch {
+			case c.Status == StatusInBag && open == nil:
+				start := c.ChangedAt
+				open = &start
+			case c.Status != StatusInBag && open != nil:
+				intervals = append(intervals, interval{*open, c.ChangedAt})
+				open = nil
+			}
+		}
+		if open != nil {
+			intervals = append(intervals, interval{*open, now})
+		}
+	}
+
+	count := 0
+	for _, t := range times {
+		for _, iv := range intervals {
+			if !t.Before(iv.start) && t.Before(iv.end) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// IsTaggedWith reports whether a TechDisc tag refers to this disc, either by
+// its name or its ID.
+func (d *Disc) IsTaggedWith(tags []string) bool {
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if strings.EqualFold(tag, d.Name) || tag == d.ID.Hex() {
+			return true
+		}
+	}
+	return false
+}
+
+func halfStep(v float64) float64 {
+	return math.Floor(v*2) / 2
+}
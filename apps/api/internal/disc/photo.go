@@ -0,0 +1,137 @@
+package disc
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"time"
+
+	// Register the decoders image.Decode accepts.
+	_ "image/gif"
+	_ "image/png"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	MaxPhotos        = 8
+	MaxPhotoBytes    = 10 << 20
+	ThumbnailMaxSide = 320
+
+	// Larger images decode into hundreds of MB of pixels.
+	maxPhotoPixels = 40_000_000
+)
+
+var ErrInvalidImage = errors.New("file is not a JPEG, PNG or GIF image")
+
+// Photo is an uploaded picture of a disc. The blob keys stay server-side;
+// clients fetch the bytes through /discs/{id}/photos/{photoId}.
+type Photo struct {
+	ID          bson.ObjectID `bson:"_id" json:"_id"`
+	Key         string        `bson:"key" json:"-"`
+	ThumbKey    string        `bson:"thumbKey" json:"-"`
+	ContentType string        `bson:"contentType" json:"contentType"`
+	Width       int           `bson:"width" json:"width"`
+	Height      int           `bson:"height" json:"height"`
+	SizeBytes   int64         `bson:"sizeBytes" json:"sizeBytes"`
+	CreatedAt   time.Time     `bson:"createdAt" json:"createdAt"`
+}
+
+// ProcessedPhoto is a validated upload plus its JPEG thumbnail.
+type ProcessedPhoto struct {
+	ContentType string
+	Ext         string
+	Width       int
+	Height      int
+	Thumbnail   []byte
+}
+
+// ProcessPhoto checks that data is an image we can serve and renders a
+// thumbnail whose longest side is at most ThumbnailMaxSide.
+func ProcessPhoto(data []byte) (*ProcessedPhoto, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width == 0 || cfg.Height == 0 || cfg.Width*cfg.Height > maxPhotoPixels {
+		return nil, ErrInvalidImage
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	var thumb bytes.Buffer
+	if err := jpeg.Encode(&thumb, Thumbnail(img, ThumbnailMaxSide), &jpeg.Options{Quality: 82}); err != nil {
+		return nil, err
+	}
+
+	p := &ProcessedPhoto{
+		Width:     cfg.Width,
+		Height:    cfg.Height,
+		Thumbnail: thumb.Bytes(),
+	}
+	switch format {
+	case "jpeg":
+		p.ContentType, p.Ext = "image/jpeg", "jpg"
+	case "png":
+		p.ContentType, p.Ext = "image/png", "png"
+	case "gif":
+		p.ContentType, p.Ext = "image/gif", "gif"
+	default:
+		return nil, ErrInvalidImage
+	}
+
+	return p, nil
+}
+
+// Thumbnail scales img down so its longest side is maxSide, averaging the
+// source pixels under each target pixel. Smaller images are only flattened
+// onto white so transparent PNGs survive the JPEG encode.
+func Thumbnail(img image.Image, maxSide int) image.Image {
+	src := image.NewRGBA(img.Bounds().Sub(img.Bounds().Min))
+	draw.Draw(src, src.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(src, src.Bounds(), img, img.Bounds().Min, draw.Over)
+
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	if sw <= maxSide && sh <= maxSide {
+		return src
+	}
+
+	dw, dh := maxSide, sh*maxSide/sw
+	if sh > sw {
+		dw, dh = sw*maxSide/sh, maxSide
+	}
+	dw, dh = max(dw, 1), max(dh, 1)
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := y*sh/dh, max((y+1)*sh/dh, y*sh/dh+1)
+		for x := 0; x < dw; x++ {
+			x0, x1 := x*sw/dw, max((x+1)*sw/dw, x*sw/dw+1)
+
+			var r, g, b, n int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					r += int(row[sx*4])
+					g += int(row[sx*4+1])
+					b += int(row[sx*4+2])
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = 0xff
+		}
+	}
+
+	return dst
+}
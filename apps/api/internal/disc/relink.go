@@ -0,0 +1,45 @@
+package disc
+
+import "go.mongodb.org/mongo-driver/v2/bson"
+
+// Outcomes of checking a disc against the catalog.
+const (
+	LinkRelinked   = "relinked"
+	LinkStaleStock = "stale_stock"
+	LinkUnresolved = "unresolved"
+)
+
+type RelinkOptions struct {
+	DryRun bool
+	// RefreshStock copies the catalog's current flight numbers into
+	// StockFlight when they differ.
+	RefreshStock bool
+	// UserID limits the run to one user's discs; empty means everyone.
+	UserID string
+}
+
+// RelinkEntry describes a disc whose catalog link needed attention.
+// StockBefore/StockAfter are set when the catalog's numbers differ from the
+// disc's stock numbers.
+type RelinkEntry struct {
+	DiscID           bson.ObjectID  `json:"discId"`
+	UserID           string         `json:"userId"`
+	Name             string         `json:"name"`
+	Brand            string         `json:"brand"`
+	Outcome          string         `json:"outcome"`
+	OldCatalogDiscID string         `json:"oldCatalogDiscId"`
+	NewCatalogDiscID string         `json:"newCatalogDiscId,omitempty"`
+	StockBefore      *FlightNumbers `json:"stockBefore,omitempty"`
+	StockAfter       *FlightNumbers `json:"stockAfter,omitempty"`
+}
+
+type RelinkReport struct {
+	DryRun         bool          `json:"dryRun"`
+	Checked        int           `json:"checked"`
+	Linked         int           `json:"linked"`
+	Relinked       int           `json:"relinked"`
+	StaleStock     int           `json:"staleStock"`
+	StockRefreshed int           `json:"stockRefreshed"`
+	Unresolved     int           `json:"unresolved"`
+	Entries        []RelinkEntry `json:"entries"`
+}
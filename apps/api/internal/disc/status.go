@@ -0,0 +1,78 @@
+package disc
+
+import (
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	StatusInBag   = "in_bag"
+	StatusBackup  = "backup"
+	StatusLost    = "lost"
+	StatusRetired = "retired"
+	StatusSold    = "sold"
+)
+
+// Statuses in the order the shelf view lists them.
+var Statuses = []string{StatusInBag, StatusBackup, StatusLost, StatusRetired, StatusSold}
+
+func IsValidStatus(status string) bool {
+	return slices.Contains(Statuses, status)
+}
+
+type StatusChange struct {
+	Status     string    `bson:"status" json:"status"`
+	ChangedAt  time.Time `bson:"changedAt" json:"changedAt"`
+	CourseName string    `bson:"courseName,omitempty" json:"courseName,omitempty"`
+	Note       string    `bson:"note,omitempty" json:"note,omitempty"`
+}
+
+type UpdateStatusInput struct {
+	Status string
+	// Bag to return the disc to when it goes back in a bag. Defaults to the
+	// bag it was last in.
+	BagID *bson.ObjectID
+	// UDisc course the disc was lost at. Only used with StatusLost.
+	CourseName string
+	// When a lost disc was found. Defaults to now.
+	RecoveredAt *time.Time
+	Note        string
+}
+
+type ShelfGroup struct {
+	Status string  `json:"status"`
+	Count  int     `json:"count"`
+	Discs  []*Disc `json:"discs"`
+}
+
+type Shelf struct {
+	Total  int          `json:"total"`
+	Groups []ShelfGroup `json:"groups"`
+}
+
+// BuildShelf groups discs by status, keeping every status present so the
+// client can render empty sections.
+func BuildShelf(discs []*Disc) *Shelf {
+	groups := make([]ShelfGroup, len(Statuses))
+	index := make(map[string]int, len(Statuses))
+	for i, status := range Statuses {
+		groups[i] = ShelfGroup{Status: status, Discs: []*Disc{}}
+		index[status] = i
+	}
+
+	for _, d := range discs {
+		i, ok := index[d.Status]
+		if !ok {
+			i = index[StatusInBag]
+		}
+		groups[i].Discs = append(groups[i].Discs, d)
+		groups[i].Count++
+	}
+
+	return &Shelf{
+		Total:  len(discs),
+		Groups: groups,
+	}
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Tidwell32/zack/apps/api/internal/requestmeta"
+	"github.com/Tidwell32/zack/apps/api/internal/services"
+	"github.com/Tidwell32/zack/apps/api/pkg/response"
+)
+
+// callerID is the logged-in user, or "" for anonymous visitors. Unlike the
+// owner fallback used to pick whose data to show, this is who is asking and
+// is what services authorize against.
+func callerID(r *http.Request) string {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta != nil && meta.User != nil {
+		return meta.User.ID
+	}
+	return ""
+}
+
+func writeAccessError(w http.ResponseWriter, err error, notFound, forbidden, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		response.Error(w, http.StatusNotFound, notFound)
+	case errors.Is(err, services.ErrForbidden):
+		response.Error(w, http.StatusForbidden, forbidden)
+	default:
+		response.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
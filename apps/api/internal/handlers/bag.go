@@ -3,9 +3,15 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/Tidwell32/zack/apps/api/internal/bag"
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
 	"github.com/Tidwell32/zack/apps/api/internal/config"
 	"github.com/Tidwell32/zack/apps/api/internal/requestmeta"
 	"github.com/Tidwell32/zack/apps/api/internal/services"
@@ -27,7 +33,9 @@ func NewBagHandler(cfg *config.Config, bagService *services.BagService) *BagHand
 }
 
 type createBagRequest struct {
-	Name string `json:"name"`
+	Name       string   `json:"name"`
+	Visibility string   `json:"visibility"`
+	ViewerIDs  []string `json:"viewerIds"`
 }
 
 // POST /bags
@@ -57,10 +65,22 @@ func (h *BagHandler) CreateBag(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	visibility, err := validateVisibility(req.Visibility)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+		return
+	}
+
+	viewerIDs, msg, ok := validateViewerIDs(req.ViewerIDs)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, msg)
+		return
+	}
+
 	b, _, err := h.bagService.CreateBag(
 		r.Context(),
 		userID,
-		bag.CreateBagInput{Name: name},
+		bag.CreateBagInput{Name: name, Visibility: visibility, ViewerIDs: viewerIDs},
 	)
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to create bag")
@@ -79,7 +99,7 @@ func (h *BagHandler) GetBagsForCurrentUser(w http.ResponseWriter, r *http.Reques
 		userID = meta.User.ID
 	}
 
-	bags, err := h.bagService.GetBagsForUser(r.Context(), userID)
+	bags, err := h.bagService.GetBagsForUser(r.Context(), userID, callerID(r))
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to fetch bags")
 		return
@@ -102,7 +122,7 @@ func (h *BagHandler) GetBag(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	bagWithDiscs, err := h.bagService.GetBagWithDiscs(r.Context(), oid)
+	bagWithDiscs, err := h.bagService.GetBagWithDiscs(r.Context(), callerID(r), oid)
 	if err != nil {
 		if errors.Is(err, services.ErrNotFound) {
 			response.Error(w, http.StatusNotFound, "bag not found")
@@ -116,7 +136,9 @@ func (h *BagHandler) GetBag(w http.ResponseWriter, r *http.Request) {
 }
 
 type updateBagRequest struct {
-	Name *string `json:"name"`
+	Name       *string   `json:"name"`
+	Visibility *string   `json:"visibility"`
+	ViewerIDs  *[]string `json:"viewerIds"`
 }
 
 // PATCH /bags/{id}
@@ -143,61 +165,357 @@ func (h *BagHandler) UpdateBag(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if userID == nil {
-		var name string
-		if req.Name != nil {
-			validatedName, err := validation.ValidateString(*req.Name,
-				validation.StringRules{Field: "name"}.
-					RequiredField().
-					Trimmed().
-					Min(2).
-					Max(50),
-			)
-			if err != nil {
-				response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
-				return
+	var input bag.UpdateBagInput
+
+	if req.Name != nil {
+		name, err := validation.ValidateString(*req.Name,
+			validation.StringRules{Field: "name"}.
+				RequiredField().
+				Trimmed().
+				Min(2).
+				Max(50),
+		)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+			return
+		}
+		input.Name = &name
+	} else if userID == nil {
+		response.Error(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	if req.Visibility != nil {
+		visibility, err := validateVisibility(*req.Visibility)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+			return
+		}
+		input.Visibility = &visibility
+	}
+
+	if req.ViewerIDs != nil {
+		viewerIDs, msg, ok := validateViewerIDs(*req.ViewerIDs)
+		if !ok {
+			response.Error(w, http.StatusBadRequest, msg)
+			return
+		}
+		input.ViewerIDs = &viewerIDs
+	}
+
+	b, _, err := h.bagService.UpdateBag(r.Context(), userID, bagID, input)
+	if err != nil {
+		writeAccessError(w, err, "bag not found", "not authorized to update this bag", "failed to update bag")
+		return
+	}
+
+	_ = response.Success(w, b)
+}
+
+// DELETE /bags/{id}?mode=shelve&targetBagId=
+func (h *BagHandler) DeleteBag(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+
+	var userID *string
+	if meta != nil && meta.User != nil {
+		userID = &meta.User.ID
+	}
+
+	bagIDStr := r.PathValue("id")
+	bagID, err := validation.ValidateObjectID(bagIDStr, "bag id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	query := r.URL.Query()
+	mode, err := validation.ValidateString(query.Get("mode"),
+		validation.StringRules{Field: "mode"}.Trimmed().In(bag.DeleteModes...),
+	)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+		return
+	}
+	if mode == "" {
+		mode = bag.DeleteModeShelve
+	}
+
+	opts := bag.DeleteOptions{Mode: mode}
+	if targetStr := query.Get("targetBagId"); targetStr != "" {
+		if mode != bag.DeleteModeMove {
+			response.Error(w, http.StatusBadRequest, "targetBagId can only be set when mode is move")
+			return
+		}
+		targetID, err := validation.ValidateObjectID(targetStr, "targetBagId")
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		opts.TargetBagID = &targetID
+	} else if mode == bag.DeleteModeMove {
+		response.Error(w, http.StatusBadRequest, "targetBagId is required when mode is move")
+		return
+	}
+
+	summary, err := h.bagService.DeleteBag(r.Context(), userID, bagID, opts)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDeleteTarget) {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeAccessError(w, err, "bag not found", "not authorized to delete this bag", "failed to delete bag")
+		return
+	}
+
+	_ = response.Success(w, summary)
+}
+
+// GET /bags/{id}/gaps?limit=3
+func (h *BagHandler) GetBagGaps(w http.ResponseWriter, r *http.Request) {
+	bagID, err := validation.ValidateObjectID(r.PathValue("id"), "bag id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit := 3
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsedLimit, err := parseLimit(limitStr); err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+			if limit > 10 {
+				limit = 10
 			}
-			name = validatedName
-		} else {
-			response.Error(w, http.StatusBadRequest, "name is required")
+		}
+	}
+
+	analysis, err := h.bagService.AnalyzeGaps(r.Context(), callerID(r), bagID, limit)
+	if err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "bag not found")
 			return
 		}
+		response.Error(w, http.StatusInternalServerError, "failed to analyze bag")
+		return
+	}
+
+	_ = response.Success(w, analysis)
+}
+
+func validateVisibility(visibility string) (string, error) {
+	if visibility == "" {
+		return bag.VisibilityPublic, nil
+	}
+	return validation.ValidateString(visibility,
+		validation.StringRules{Field: "visibility"}.
+			Trimmed().
+			In(bag.VisibilityPrivate, bag.VisibilityPublic),
+	)
+}
+
+func validateViewerIDs(ids []string) ([]string, string, bool) {
+	if len(ids) > 50 {
+		return nil, "a bag can have at most 50 viewers", false
+	}
 
-		b, _, err := h.bagService.UpdateBag(
-			r.Context(),
-			userID,
-			bagID,
-			bag.UpdateBagInput{Name: name},
+	validated := make([]string, 0, len(ids))
+	for _, id := range ids {
+		viewerID, err := validation.ValidateString(id,
+			validation.StringRules{Field: "viewer id"}.RequiredField().Trimmed().Max(100),
 		)
 		if err != nil {
-			response.Error(w, http.StatusInternalServerError, "failed to update bag")
+			return nil, validation.ToHTTPMessage(err), false
+		}
+		validated = append(validated, viewerID)
+	}
+
+	return validated, "", true
+}
+
+type createShareRequest struct {
+	ExpiresInDays *int `json:"expiresInDays"`
+}
+
+// POST /bags/{id}/shares
+func (h *BagHandler) CreateShareToken(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to share bags")
+		return
+	}
+
+	bagID, err := validation.ValidateObjectID(r.PathValue("id"), "bag id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req createShareRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	var expiresAt *time.Time
+	if req.ExpiresInDays != nil {
+		days, err := validation.ValidateInt(*req.ExpiresInDays, validation.IntRules{Field: "expiresInDays"}.MinValue(1).MaxValue(365))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
 			return
 		}
+		t := time.Now().UTC().AddDate(0, 0, days)
+		expiresAt = &t
+	}
+
+	share, err := h.bagService.CreateShareToken(r.Context(), meta.User.ID, bagID, expiresAt)
+	if err != nil {
+		writeAccessError(w, err, "bag not found", "not authorized to share this bag", "failed to create share link")
+		return
+	}
+
+	_ = response.Success(w, share)
+}
+
+// GET /bags/{id}/shares
+func (h *BagHandler) GetShareTokens(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to view share links")
+		return
+	}
+
+	bagID, err := validation.ValidateObjectID(r.PathValue("id"), "bag id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	shares, err := h.bagService.GetShareTokens(r.Context(), meta.User.ID, bagID)
+	if err != nil {
+		writeAccessError(w, err, "bag not found", "not authorized to view share links for this bag", "failed to fetch share links")
+		return
+	}
+
+	_ = response.Success(w, shares)
+}
+
+// DELETE /bags/{id}/shares/{token}
+func (h *BagHandler) RevokeShareToken(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to revoke share links")
+		return
+	}
+
+	bagID, err := validation.ValidateObjectID(r.PathValue("id"), "bag id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-		_ = response.Success(w, b)
+	if err := h.bagService.RevokeShareToken(r.Context(), meta.User.ID, bagID, r.PathValue("token")); err != nil {
+		writeAccessError(w, err, "share link not found", "not authorized to revoke share links for this bag", "failed to revoke share link")
 		return
 	}
 
-	existing, err := h.bagService.GetBagByID(r.Context(), bagID)
+	_ = response.Success(w, map[string]string{"message": "share link revoked"})
+}
+
+// GET /share/bags/{token}
+func (h *BagHandler) GetSharedBag(w http.ResponseWriter, r *http.Request) {
+	shared, err := h.bagService.GetSharedBag(r.Context(), r.PathValue("token"))
 	if err != nil {
 		if errors.Is(err, services.ErrNotFound) {
-			response.Error(w, http.StatusNotFound, "bag not found")
+			response.Error(w, http.StatusNotFound, "share link not found or expired")
 			return
 		}
-		response.Error(w, http.StatusInternalServerError, "failed to fetch bag")
+		response.Error(w, http.StatusInternalServerError, "failed to fetch shared bag")
 		return
 	}
 
-	if existing.UserID != *userID {
-		response.Error(w, http.StatusForbidden, "not authorized to update this bag")
+	_ = response.Success(w, shared)
+}
+
+// GET /bags/{id}/export?format=json|csv
+func (h *BagHandler) ExportBag(w http.ResponseWriter, r *http.Request) {
+	bagID, err := validation.ValidateObjectID(r.PathValue("id"), "bag id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	name := existing.Name
-	if req.Name != nil {
-		validatedName, err := validation.ValidateString(*req.Name,
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = bag.FormatJSON
+	}
+	if format != bag.FormatJSON && format != bag.FormatCSV {
+		response.Error(w, http.StatusBadRequest, "format must be json or csv")
+		return
+	}
+
+	export, err := h.bagService.ExportBag(r.Context(), callerID(r), bagID)
+	if err != nil {
+		writeAccessError(w, err, "bag not found", "not authorized to export this bag", "failed to export bag")
+		return
+	}
+
+	filename := catalog.Slugify(export.Name)
+	if filename == "" {
+		filename = "bag"
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, filename, format))
+
+	if format == bag.FormatCSV {
+		w.Header().Set("Content-Type", "text/csv")
+		_ = export.WriteCSV(w)
+		return
+	}
+
+	_ = response.Success(w, export)
+}
+
+// POST /bags/import
+// Multipart form: file (required), name and format (optional).
+func (h *BagHandler) ImportBag(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to import bags")
+		return
+	}
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		response.Error(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to read import file")
+		return
+	}
+
+	format := r.FormValue("format")
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(header.Filename)) {
+		case ".json":
+			format = bag.FormatJSON
+		case ".csv":
+			format = bag.FormatCSV
+		}
+	}
+
+	var name string
+	if rawName := r.FormValue("name"); rawName != "" {
+		name, err = validation.ValidateString(rawName,
 			validation.StringRules{Field: "name"}.
-				RequiredField().
 				Trimmed().
 				Min(2).
 				Max(50),
@@ -206,74 +524,188 @@ func (h *BagHandler) UpdateBag(w http.ResponseWriter, r *http.Request) {
 			response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
 			return
 		}
-		name = validatedName
 	}
 
-	b, _, err := h.bagService.UpdateBag(
-		r.Context(),
-		userID,
-		bagID,
-		bag.UpdateBagInput{Name: name},
-	)
+	parsed, err := bag.ParseImport(data, format)
 	if err != nil {
-		if errors.Is(err, services.ErrNotFound) {
-			response.Error(w, http.StatusNotFound, "bag not found")
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "failed to update bag")
+		response.Error(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	_ = response.Success(w, b)
+	if runes := []rune(parsed.Name); len(runes) > 50 {
+		parsed.Name = strings.TrimSpace(string(runes[:50]))
+	}
+
+	result, err := h.bagService.ImportBag(r.Context(), meta.User.ID, parsed, name)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to import bag")
+		return
+	}
+
+	_ = response.Success(w, result)
 }
 
-// DELETE /bags/{id}
-func (h *BagHandler) DeleteBag(w http.ResponseWriter, r *http.Request) {
+type bagNameRequest struct {
+	Name string `json:"name"`
+}
+
+// decodeBagName reads an optional {"name": "..."} body. An empty body is
+// allowed and yields an empty name.
+func decodeBagName(w http.ResponseWriter, r *http.Request, required bool) (string, bool) {
+	var req bagNameRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return "", false
+	}
+
+	if req.Name == "" && !required {
+		return "", true
+	}
+
+	name, err := validation.ValidateString(req.Name,
+		validation.StringRules{Field: "name"}.
+			RequiredField().
+			Trimmed().
+			Min(2).
+			Max(50),
+	)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+		return "", false
+	}
+
+	return name, true
+}
+
+// POST /bags/{id}/clone
+func (h *BagHandler) CloneBag(w http.ResponseWriter, r *http.Request) {
 	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to clone bags")
+		return
+	}
 
-	var userID *string
-	if meta != nil && meta.User != nil {
-		userID = &meta.User.ID
+	bagID, err := validation.ValidateObjectID(r.PathValue("id"), "bag id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	bagIDStr := r.PathValue("id")
-	bagID, err := validation.ValidateObjectID(bagIDStr, "bag id")
+	name, ok := decodeBagName(w, r, false)
+	if !ok {
+		return
+	}
+
+	cloned, err := h.bagService.CloneBag(r.Context(), meta.User.ID, bagID, name)
+	if err != nil {
+		writeAccessError(w, err, "bag not found", "not authorized to clone this bag", "failed to clone bag")
+		return
+	}
+
+	_ = response.Success(w, cloned)
+}
+
+// POST /bags/{id}/template
+func (h *BagHandler) SaveTemplate(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to save templates")
+		return
+	}
+
+	bagID, err := validation.ValidateObjectID(r.PathValue("id"), "bag id")
 	if err != nil {
 		response.Error(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	if userID == nil {
-		_, err := h.bagService.DeleteBag(r.Context(), userID, bagID)
-		if err != nil {
-			response.Error(w, http.StatusInternalServerError, "failed to delete bag")
-			return
-		}
+	name, ok := decodeBagName(w, r, true)
+	if !ok {
+		return
+	}
 
-		_ = response.Success(w, map[string]string{"message": "bag deleted successfully"})
+	t, err := h.bagService.SaveTemplate(r.Context(), meta.User.ID, bagID, name)
+	if err != nil {
+		writeAccessError(w, err, "bag not found", "not authorized to save this bag as a template", "failed to save template")
+		return
+	}
+
+	_ = response.Success(w, t)
+}
+
+// GET /bag-templates
+func (h *BagHandler) GetTemplates(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to view templates")
 		return
 	}
 
-	existing, err := h.bagService.GetBagByID(r.Context(), bagID)
+	templates, err := h.bagService.GetTemplates(r.Context(), meta.User.ID)
 	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch templates")
+		return
+	}
+
+	_ = response.Success(w, templates)
+}
+
+// DELETE /bag-templates/{id}
+func (h *BagHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to delete templates")
+		return
+	}
+
+	templateID, err := validation.ValidateObjectID(r.PathValue("id"), "template id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.bagService.DeleteTemplate(r.Context(), meta.User.ID, templateID); err != nil {
 		if errors.Is(err, services.ErrNotFound) {
-			response.Error(w, http.StatusNotFound, "bag not found")
+			response.Error(w, http.StatusNotFound, "template not found")
 			return
 		}
-		response.Error(w, http.StatusInternalServerError, "failed to fetch bag")
+		response.Error(w, http.StatusInternalServerError, "failed to delete template")
+		return
+	}
+
+	_ = response.Success(w, map[string]string{"message": "template deleted successfully"})
+}
+
+// POST /bag-templates/{id}/instantiate
+func (h *BagHandler) InstantiateTemplate(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to use templates")
 		return
 	}
 
-	if existing.UserID != *userID {
-		response.Error(w, http.StatusForbidden, "not authorized to delete this bag")
+	templateID, err := validation.ValidateObjectID(r.PathValue("id"), "template id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	_, err = h.bagService.DeleteBag(r.Context(), userID, bagID)
+	name, ok := decodeBagName(w, r, false)
+	if !ok {
+		return
+	}
+
+	created, err := h.bagService.InstantiateTemplate(r.Context(), meta.User.ID, templateID, name)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "failed to delete bag")
+		if errors.Is(err, services.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "template not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "failed to create bag from template")
 		return
 	}
 
-	_ = response.Success(w, map[string]string{"message": "bag deleted successfully"})
+	_ = response.Success(w, created)
 }
@@ -1,21 +1,33 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+	"github.com/Tidwell32/zack/apps/api/internal/config"
 	"github.com/Tidwell32/zack/apps/api/internal/repository"
+	"github.com/Tidwell32/zack/apps/api/internal/requestmeta"
+	"github.com/Tidwell32/zack/apps/api/internal/services"
 	"github.com/Tidwell32/zack/apps/api/pkg/response"
+	"github.com/Tidwell32/zack/apps/api/pkg/validation"
 )
 
 type CatalogHandler struct {
-	catalogRepo repository.DiscCatalogRepository
+	cfg            *config.Config
+	catalogService *services.CatalogService
 }
 
-func NewCatalogHandler(catalogRepo repository.DiscCatalogRepository) *CatalogHandler {
+func NewCatalogHandler(cfg *config.Config, catalogService *services.CatalogService) *CatalogHandler {
 	return &CatalogHandler{
-		catalogRepo: catalogRepo,
+		cfg:            cfg,
+		catalogService: catalogService,
 	}
 }
 
@@ -36,7 +48,7 @@ func (h *CatalogHandler) SearchDiscs(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	discs, err := h.catalogRepo.Search(r.Context(), query, limit)
+	discs, err := h.catalogService.Search(r.Context(), h.userID(r), query, limit)
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to search catalog")
 		return
@@ -45,6 +57,242 @@ func (h *CatalogHandler) SearchDiscs(w http.ResponseWriter, r *http.Request) {
 	_ = response.Success(w, discs)
 }
 
+// GET /catalog/discs?q=&brand=innova,mvp&category=&stability=&minSpeed=&maxSpeed=&minGlide=...&cursor=&limit=20
+func (h *CatalogHandler) FacetedSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	q := repository.CatalogSearchQuery{
+		Text:           query.Get("q"),
+		BrandSlugs:     slugList(query["brand"]),
+		CategorySlugs:  slugList(query["category"]),
+		StabilitySlugs: slugList(query["stability"]),
+		Limit:          20,
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if parsedLimit, err := parseLimit(limitStr); err == nil && parsedLimit > 0 {
+			q.Limit = min(parsedLimit, 100)
+		}
+	}
+
+	ranges := []struct {
+		name string
+		dst  *repository.FloatRange
+	}{
+		{"Speed", &q.Speed},
+		{"Glide", &q.Glide},
+		{"Turn", &q.Turn},
+		{"Fade", &q.Fade},
+	}
+	for _, rg := range ranges {
+		var err error
+		if rg.dst.Min, err = parseOptionalFloat(query.Get("min" + rg.name)); err != nil {
+			response.Error(w, http.StatusBadRequest, "min"+rg.name+" must be a valid number")
+			return
+		}
+		if rg.dst.Max, err = parseOptionalFloat(query.Get("max" + rg.name)); err != nil {
+			response.Error(w, http.StatusBadRequest, "max"+rg.name+" must be a valid number")
+			return
+		}
+		if rg.dst.Min != nil && rg.dst.Max != nil && *rg.dst.Min > *rg.dst.Max {
+			response.Error(w, http.StatusBadRequest, fmt.Sprintf("min%s cannot be greater than max%s", rg.name, rg.name))
+			return
+		}
+	}
+
+	if cursor := query.Get("cursor"); cursor != "" {
+		after, err := catalog.DecodeSearchCursor(cursor)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		q.After = after
+	}
+
+	page, err := h.catalogService.FacetedSearch(r.Context(), h.userID(r), q)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to search catalog")
+		return
+	}
+
+	_ = response.Success(w, page)
+}
+
+// GET /catalog/discs/{id}/similar?brand=&differentBrand=true&category=&limit=10
+func (h *CatalogHandler) SimilarDiscs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	opts := catalog.SimilarOptions{
+		BrandSlugs:    slugList(query["brand"]),
+		CategorySlugs: slugList(query["category"]),
+		Limit:         10,
+	}
+
+	if v := query.Get("differentBrand"); v != "" {
+		differentBrand, err := strconv.ParseBool(v)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "differentBrand must be true or false")
+			return
+		}
+		opts.DifferentBrand = differentBrand
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if parsedLimit, err := parseLimit(limitStr); err == nil && parsedLimit > 0 {
+			opts.Limit = min(parsedLimit, 50)
+		}
+	}
+
+	discs, err := h.catalogService.Similar(r.Context(), h.userID(r), r.PathValue("id"), opts)
+	if err != nil {
+		if errors.Is(err, services.ErrCatalogDiscNotFound) {
+			response.Error(w, http.StatusNotFound, "catalog disc not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "failed to find similar discs")
+		return
+	}
+
+	_ = response.Success(w, discs)
+}
+
+// GET /catalog/plastics?brand=innova&q=cha&limit=20
+func (h *CatalogHandler) SearchPlastics(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit := 20
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if parsedLimit, err := parseLimit(limitStr); err == nil && parsedLimit > 0 {
+			limit = min(parsedLimit, 100)
+		}
+	}
+
+	plastics, err := h.catalogService.SearchPlastics(r.Context(), catalog.Slugify(query.Get("brand")), query.Get("q"), limit)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to search plastics")
+		return
+	}
+
+	_ = response.Success(w, plastics)
+}
+
+// GET /catalog/discs/{id}/plastics
+func (h *CatalogHandler) GetPlasticOptions(w http.ResponseWriter, r *http.Request) {
+	options, err := h.catalogService.PlasticOptions(r.Context(), h.userID(r), r.PathValue("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrCatalogDiscNotFound) {
+			response.Error(w, http.StatusNotFound, "catalog disc not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "failed to fetch plastics")
+		return
+	}
+
+	_ = response.Success(w, options)
+}
+
+// GET /catalog/changes?since=2025-01-01&kind=added,updated&brand=&limit=50
+func (h *CatalogHandler) GetCatalogChanges(w http.ResponseWriter, r *http.Request) {
+	q, ok := h.changeQuery(w, r, 30)
+	if !ok {
+		return
+	}
+
+	for _, kind := range slugList(r.URL.Query()["kind"]) {
+		if kind != catalog.ChangeAdded && kind != catalog.ChangeUpdated {
+			response.Error(w, http.StatusBadRequest, "kind must be added or updated")
+			return
+		}
+		q.Kinds = append(q.Kinds, kind)
+	}
+
+	changes, err := h.catalogService.GetCatalogChanges(r.Context(), q)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch catalog changes")
+		return
+	}
+
+	_ = response.Success(w, changes)
+}
+
+// GET /catalog/new?since=2025-01-01&brand=&limit=50
+func (h *CatalogHandler) GetNewReleases(w http.ResponseWriter, r *http.Request) {
+	q, ok := h.changeQuery(w, r, 90)
+	if !ok {
+		return
+	}
+
+	releases, err := h.catalogService.NewReleases(r.Context(), h.userID(r), q)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch new releases")
+		return
+	}
+
+	_ = response.Success(w, releases)
+}
+
+// changeQuery parses the since, brand and limit parameters shared by the
+// change feeds. since is a date in the caller's timezone or an RFC 3339
+// timestamp, defaulting to defaultDays ago.
+func (h *CatalogHandler) changeQuery(w http.ResponseWriter, r *http.Request, defaultDays int) (repository.CatalogChangeQuery, bool) {
+	query := r.URL.Query()
+
+	q := repository.CatalogChangeQuery{
+		Since:      time.Now().UTC().AddDate(0, 0, -defaultDays),
+		BrandSlugs: slugList(query["brand"]),
+		Limit:      50,
+	}
+
+	if since := query.Get("since"); since != "" {
+		loc := time.UTC
+		if meta := requestmeta.GetMeta(r.Context()); meta != nil && meta.Timezone != nil {
+			loc = meta.Timezone
+		}
+
+		parsed, err := time.ParseInLocation("2006-01-02", since, loc)
+		if err != nil {
+			if parsed, err = time.Parse(time.RFC3339, since); err != nil {
+				response.Error(w, http.StatusBadRequest, "invalid since format, use YYYY-MM-DD or RFC 3339")
+				return q, false
+			}
+		}
+		q.Since = parsed
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if parsedLimit, err := parseLimit(limitStr); err == nil && parsedLimit > 0 {
+			q.Limit = min(parsedLimit, 200)
+		}
+	}
+
+	return q, true
+}
+
+// slugList accepts repeated and comma-separated values and normalizes them
+// to slugs, so both "axiom-discs" and "Axiom Discs" work.
+func slugList(values []string) []string {
+	var slugs []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if slug := catalog.Slugify(part); slug != "" {
+				slugs = append(slugs, slug)
+			}
+		}
+	}
+	return slugs
+}
+
+func parseOptionalFloat(s string) (*float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
 func parseLimit(s string) (int, error) {
 	var limit int
 	_, err := fmt.Sscanf(s, "%d", &limit)
@@ -100,14 +348,35 @@ func (h *CatalogHandler) SuggestDiscs(w http.ResponseWriter, r *http.Request) {
 	}
 
 	criteria := repository.SuggestCriteria{
-		MinSpeed:     minSpeed,
-		MaxSpeed:     maxSpeed,
-		MinStability: minStability,
-		MaxStability: maxStability,
-		Limit:        limit,
+		MinSpeed:      minSpeed,
+		MaxSpeed:      maxSpeed,
+		MinStability:  minStability,
+		MaxStability:  maxStability,
+		CategorySlugs: slugList(query["category"]),
+		Limit:         limit,
+	}
+
+	if criteria.Glide.Min, err = parseOptionalFloat(query.Get("minGlide")); err != nil {
+		response.Error(w, http.StatusBadRequest, "minGlide must be a valid number")
+		return
+	}
+	if criteria.Glide.Max, err = parseOptionalFloat(query.Get("maxGlide")); err != nil {
+		response.Error(w, http.StatusBadRequest, "maxGlide must be a valid number")
+		return
+	}
+	if criteria.Glide.Min != nil && criteria.Glide.Max != nil && *criteria.Glide.Min > *criteria.Glide.Max {
+		response.Error(w, http.StatusBadRequest, "minGlide cannot be greater than maxGlide")
+		return
 	}
 
-	discs, err := h.catalogRepo.Suggest(r.Context(), criteria)
+	if v := query.Get("excludeOwned"); v != "" {
+		if criteria.ExcludeOwned, err = strconv.ParseBool(v); err != nil {
+			response.Error(w, http.StatusBadRequest, "excludeOwned must be true or false")
+			return
+		}
+	}
+
+	discs, err := h.catalogService.Suggest(r.Context(), h.userID(r), criteria)
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to suggest discs")
 		return
@@ -122,3 +391,358 @@ func parseFloat(s string) (float64, error) {
 	}
 	return strconv.ParseFloat(s, 64)
 }
+
+// userID picks whose custom discs to include: the caller, or the owner for
+// anonymous visitors.
+func (h *CatalogHandler) userID(r *http.Request) string {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta != nil && meta.User != nil {
+		return meta.User.ID
+	}
+	return h.cfg.OwnerUserID
+}
+
+type customDiscRequest struct {
+	Name     string  `json:"name"`
+	Brand    string  `json:"brand"`
+	Category string  `json:"category"`
+	Speed    float64 `json:"speed"`
+	Glide    float64 `json:"glide"`
+	Turn     float64 `json:"turn"`
+	Fade     float64 `json:"fade"`
+}
+
+func (req customDiscRequest) validate() (catalog.CustomDiscInput, error) {
+	name, err := validation.ValidateString(req.Name,
+		validation.StringRules{Field: "name"}.RequiredField().Trimmed().Min(1).Max(50),
+	)
+	if err != nil {
+		return catalog.CustomDiscInput{}, errors.New(validation.ToHTTPMessage(err))
+	}
+
+	brand, err := validation.ValidateString(req.Brand,
+		validation.StringRules{Field: "brand"}.RequiredField().Trimmed().Min(1).Max(50),
+	)
+	if err != nil {
+		return catalog.CustomDiscInput{}, errors.New(validation.ToHTTPMessage(err))
+	}
+
+	category, err := validation.ValidateString(req.Category,
+		validation.StringRules{Field: "category"}.RequiredField().Trimmed().In(catalog.Categories...),
+	)
+	if err != nil {
+		return catalog.CustomDiscInput{}, errors.New(validation.ToHTTPMessage(err))
+	}
+
+	flightRanges := []struct {
+		field    string
+		value    float64
+		min, max float64
+	}{
+		{"speed", req.Speed, 1, 15},
+		{"glide", req.Glide, 0, 7},
+		{"turn", req.Turn, -6, 2},
+		{"fade", req.Fade, 0, 6},
+	}
+	for _, fr := range flightRanges {
+		if fr.value < fr.min || fr.value > fr.max {
+			return catalog.CustomDiscInput{}, fmt.Errorf("%s must be between %g and %g", fr.field, fr.min, fr.max)
+		}
+	}
+
+	return catalog.CustomDiscInput{
+		Name:     name,
+		Brand:    brand,
+		Category: category,
+		Speed:    req.Speed,
+		Glide:    req.Glide,
+		Turn:     req.Turn,
+		Fade:     req.Fade,
+	}, nil
+}
+
+// decodeCustomDisc reads and validates a custom disc body, writing the
+// error response itself.
+func decodeCustomDisc(w http.ResponseWriter, r *http.Request) (catalog.CustomDiscInput, bool) {
+	var req customDiscRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return catalog.CustomDiscInput{}, false
+	}
+
+	input, err := req.validate()
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return catalog.CustomDiscInput{}, false
+	}
+	return input, true
+}
+
+// GET /catalog/custom-discs
+func (h *CatalogHandler) GetCustomDiscs(w http.ResponseWriter, r *http.Request) {
+	discs, err := h.catalogService.GetCustomDiscs(r.Context(), h.userID(r))
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch custom discs")
+		return
+	}
+
+	_ = response.Success(w, discs)
+}
+
+// POST /catalog/custom-discs
+func (h *CatalogHandler) CreateCustomDisc(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to create custom discs")
+		return
+	}
+
+	input, ok := decodeCustomDisc(w, r)
+	if !ok {
+		return
+	}
+
+	d, err := h.catalogService.CreateCustomDisc(r.Context(), meta.User.ID, input)
+	if err != nil {
+		if errors.Is(err, services.ErrCatalogDiscExists) {
+			response.Error(w, http.StatusConflict, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "failed to create custom disc")
+		return
+	}
+
+	_ = response.Success(w, d)
+}
+
+// PUT /catalog/custom-discs/{id}
+func (h *CatalogHandler) UpdateCustomDisc(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to update custom discs")
+		return
+	}
+
+	id, err := validation.ValidateObjectID(r.PathValue("id"), "custom disc id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	input, ok := decodeCustomDisc(w, r)
+	if !ok {
+		return
+	}
+
+	d, err := h.catalogService.UpdateCustomDisc(r.Context(), meta.User.ID, id, input)
+	if err != nil {
+		if errors.Is(err, services.ErrCatalogDiscExists) {
+			response.Error(w, http.StatusConflict, err.Error())
+			return
+		}
+		writeAccessError(w, err, "custom disc not found", "not authorized to update this disc", "failed to update custom disc")
+		return
+	}
+
+	_ = response.Success(w, d)
+}
+
+// DELETE /catalog/custom-discs/{id}
+func (h *CatalogHandler) DeleteCustomDisc(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to delete custom discs")
+		return
+	}
+
+	id, err := validation.ValidateObjectID(r.PathValue("id"), "custom disc id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.catalogService.DeleteCustomDisc(r.Context(), meta.User.ID, id); err != nil {
+		writeAccessError(w, err, "custom disc not found", "not authorized to delete this disc", "failed to delete custom disc")
+		return
+	}
+
+	_ = response.Success(w, map[string]string{"message": "custom disc deleted successfully"})
+}
+
+type catalogOverrideRequest struct {
+	Category  *string  `json:"category"`
+	Stability *string  `json:"stability"`
+	Speed     *float64 `json:"speed"`
+	Glide     *float64 `json:"glide"`
+	Turn      *float64 `json:"turn"`
+	Fade      *float64 `json:"fade"`
+	Pic       *string  `json:"pic"`
+}
+
+func (req catalogOverrideRequest) validate() (catalog.OverrideFields, error) {
+	var fields catalog.OverrideFields
+
+	strs := []struct {
+		field string
+		value *string
+		dst   **string
+		rules validation.StringRules
+	}{
+		{"category", req.Category, &fields.Category, validation.StringRules{Field: "category"}.RequiredField().Trimmed().In(catalog.Categories...)},
+		{"stability", req.Stability, &fields.Stability, validation.StringRules{Field: "stability"}.RequiredField().Trimmed().In(catalog.Stabilities...)},
+		{"pic", req.Pic, &fields.Pic, validation.StringRules{Field: "pic"}.RequiredField().Trimmed().Max(500)},
+	}
+	for _, s := range strs {
+		if s.value == nil {
+			continue
+		}
+		v, err := validation.ValidateString(*s.value, s.rules)
+		if err != nil {
+			return fields, errors.New(validation.ToHTTPMessage(err))
+		}
+		*s.dst = &v
+	}
+	if fields.Pic != nil && !strings.HasPrefix(*fields.Pic, "https://") && !strings.HasPrefix(*fields.Pic, "http://") {
+		return fields, errors.New("pic must be an http(s) URL")
+	}
+
+	flightRanges := []struct {
+		field    string
+		value    *float64
+		dst      **float64
+		min, max float64
+	}{
+		{"speed", req.Speed, &fields.Speed, 1, 15},
+		{"glide", req.Glide, &fields.Glide, 0, 7},
+		{"turn", req.Turn, &fields.Turn, -6, 2},
+		{"fade", req.Fade, &fields.Fade, 0, 6},
+	}
+	for _, fr := range flightRanges {
+		if fr.value == nil {
+			continue
+		}
+		if *fr.value < fr.min || *fr.value > fr.max {
+			return fields, fmt.Errorf("%s must be between %g and %g", fr.field, fr.min, fr.max)
+		}
+		*fr.dst = fr.value
+	}
+
+	if fields == (catalog.OverrideFields{}) {
+		return fields, errors.New("at least one field is required")
+	}
+
+	return fields, nil
+}
+
+// PATCH /catalog/discs/{id}
+func (h *CatalogHandler) OverrideCatalogDisc(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to edit the catalog")
+		return
+	}
+	if !meta.User.IsOwner {
+		response.Error(w, http.StatusForbidden, "only the site owner can edit the catalog")
+		return
+	}
+
+	id, err := validation.ValidateObjectID(r.PathValue("id"), "catalog disc id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req catalogOverrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	fields, err := req.validate()
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	d, err := h.catalogService.OverrideCatalogDisc(r.Context(), meta.User.ID, id, fields)
+	if err != nil {
+		if errors.Is(err, services.ErrCatalogDiscNotFound) {
+			response.Error(w, http.StatusNotFound, "catalog disc not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "failed to update catalog disc")
+		return
+	}
+
+	_ = response.Success(w, d)
+}
+
+// DELETE /catalog/discs/{id}/override?field=speed,turn
+// Clears the named fields, or every override when no field is given, and
+// puts the DiscIt values back.
+func (h *CatalogHandler) ClearCatalogOverride(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to edit the catalog")
+		return
+	}
+	if !meta.User.IsOwner {
+		response.Error(w, http.StatusForbidden, "only the site owner can edit the catalog")
+		return
+	}
+
+	id, err := validation.ValidateObjectID(r.PathValue("id"), "catalog disc id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	fields := slugList(r.URL.Query()["field"])
+	for _, f := range fields {
+		if !slices.Contains(catalog.OverrideFieldNames, f) {
+			response.Error(w, http.StatusBadRequest, "field must be one of "+strings.Join(catalog.OverrideFieldNames, ", "))
+			return
+		}
+	}
+
+	d, err := h.catalogService.ClearCatalogOverride(r.Context(), meta.User.ID, id, fields)
+	if err != nil {
+		if errors.Is(err, services.ErrCatalogDiscNotFound) {
+			response.Error(w, http.StatusNotFound, "catalog disc not found")
+			return
+		}
+		writeAccessError(w, err, "catalog disc has no overrides", "not authorized to edit the catalog", "failed to clear catalog overrides")
+		return
+	}
+
+	_ = response.Success(w, d)
+}
+
+// GET /catalog/discs/{id}/override
+func (h *CatalogHandler) GetCatalogOverride(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to view catalog edits")
+		return
+	}
+	if !meta.User.IsOwner {
+		response.Error(w, http.StatusForbidden, "only the site owner can view catalog edits")
+		return
+	}
+
+	id, err := validation.ValidateObjectID(r.PathValue("id"), "catalog disc id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	override, err := h.catalogService.GetCatalogOverride(r.Context(), id)
+	if err != nil {
+		writeAccessError(w, err, "catalog disc has no overrides", "not authorized to view catalog edits", "failed to fetch catalog overrides")
+		return
+	}
+
+	_ = response.Success(w, override)
+}
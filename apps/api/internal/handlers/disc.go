@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/Tidwell32/zack/apps/api/internal/config"
 	"github.com/Tidwell32/zack/apps/api/internal/disc"
@@ -81,7 +84,11 @@ func (h *DiscHandler) AddDiscToBag(w http.ResponseWriter, r *http.Request) {
 		},
 	)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "failed to create disc")
+		if errors.Is(err, services.ErrCatalogDiscNotFound) || errors.Is(err, services.ErrUnknownPlastic) {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeAccessError(w, err, "bag not found", "not authorized to add discs to this bag", "failed to create disc")
 		return
 	}
 
@@ -97,9 +104,9 @@ func (h *DiscHandler) GetDiscsForBag(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	discs, err := h.discService.GetDiscsForBag(r.Context(), bagID)
+	discs, err := h.discService.GetDiscsForBag(r.Context(), callerID(r), bagID)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "failed to fetch discs")
+		writeAccessError(w, err, "bag not found", "not authorized to view this bag", "failed to fetch discs")
 		return
 	}
 
@@ -107,12 +114,25 @@ func (h *DiscHandler) GetDiscsForBag(w http.ResponseWriter, r *http.Request) {
 }
 
 type updateDiscRequest struct {
-	BagID          *string             `json:"bagId"`
-	AdjustedFlight *disc.FlightNumbers `json:"adjustedFlight"`
-	Notes          *string             `json:"notes"`
-	ColorHex       *string             `json:"colorHex"`
-	Weight         *int                `json:"weight"`
-	Plastic        *string             `json:"plastic"`
+	BagID          *string        `json:"bagId"`
+	AdjustedFlight optionalFlight `json:"adjustedFlight"`
+	FlightReason   *string        `json:"flightReason"`
+	Notes          *string        `json:"notes"`
+	ColorHex       *string        `json:"colorHex"`
+	Weight         *int           `json:"weight"`
+	Plastic        *string        `json:"plastic"`
+}
+
+// optionalFlight tells an omitted adjustedFlight, which leaves the numbers
+// alone, from an explicit null, which resets them to stock.
+type optionalFlight struct {
+	Set    bool
+	Flight *disc.FlightNumbers
+}
+
+func (f *optionalFlight) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	return json.Unmarshal(data, &f.Flight)
 }
 
 // PATCH /discs/{id}
@@ -173,94 +193,318 @@ func (h *DiscHandler) UpdateDisc(w http.ResponseWriter, r *http.Request) {
 	if req.Notes != nil {
 		updateInput.Notes = *req.Notes
 	}
-	if req.AdjustedFlight != nil {
-		updateInput.AdjustedFlight = req.AdjustedFlight
+	if req.AdjustedFlight.Set {
+		updateInput.AdjustedFlight = req.AdjustedFlight.Flight
+		updateInput.ResetFlight = req.AdjustedFlight.Flight == nil
 	}
-
-	if userID == nil {
-		d, _, err := h.discService.UpdateDisc(ctx, userID, discID, updateInput)
+	if req.FlightReason != nil {
+		reason, err := validation.ValidateString(*req.FlightReason,
+			validation.StringRules{Field: "flightReason"}.Trimmed().Max(200),
+		)
 		if err != nil {
-			response.Error(w, http.StatusInternalServerError, "failed to update disc")
+			response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
 			return
 		}
+		updateInput.FlightReason = reason
+	}
 
-		_ = response.Success(w, d)
+	d, _, err := h.discService.UpdateDisc(ctx, userID, discID, updateInput)
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownPlastic) {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeAccessError(w, err, "disc or bag not found", "not authorized to update this disc", "failed to update disc")
 		return
 	}
 
-	existingDisc, err := h.discService.GetDiscByID(ctx, discID)
+	_ = response.Success(w, d)
+}
+
+// DELETE /discs/{id}
+func (h *DiscHandler) DeleteDisc(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+
+	var userID *string
+	if meta != nil && meta.User != nil {
+		userID = &meta.User.ID
+	}
+
+	discIDStr := r.PathValue("id")
+	discID, err := validation.ValidateObjectID(discIDStr, "disc id")
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "failed to fetch disc")
+		response.Error(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	if existingDisc == nil {
-		response.Error(w, http.StatusNotFound, "disc not found")
+
+	if _, err := h.discService.DeleteDisc(r.Context(), userID, discID); err != nil {
+		writeAccessError(w, err, "disc not found", "not authorized to delete this disc", "failed to delete disc")
 		return
 	}
 
-	if existingDisc.UserID != *userID {
-		response.Error(w, http.StatusForbidden, "not authorized to update this disc")
+	_ = response.Success(w, map[string]string{"message": "disc deleted successfully"})
+}
+
+type updateDiscStatusRequest struct {
+	Status      string  `json:"status"`
+	BagID       *string `json:"bagId"`
+	CourseName  string  `json:"courseName"`
+	RecoveredAt *string `json:"recoveredAt"`
+	Note        string  `json:"note"`
+}
+
+// PUT /discs/{id}/status
+func (h *DiscHandler) UpdateDiscStatus(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to change disc status")
 		return
 	}
 
-	d, _, err := h.discService.UpdateDisc(ctx, userID, discID, updateInput)
+	discID, err := validation.ValidateObjectID(r.PathValue("id"), "disc id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req updateDiscStatusRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	status, err := validation.ValidateString(req.Status,
+		validation.StringRules{Field: "status"}.RequiredField().Trimmed().In(disc.Statuses...),
+	)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "failed to update disc")
+		response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+		return
+	}
+
+	input := disc.UpdateStatusInput{Status: status}
+
+	if req.BagID != nil {
+		if status != disc.StatusInBag {
+			response.Error(w, http.StatusBadRequest, "bagId can only be set when putting a disc in a bag")
+			return
+		}
+		bagID, err := validation.ValidateObjectID(*req.BagID, "bag id")
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		input.BagID = &bagID
+	}
+
+	if req.CourseName != "" {
+		if status != disc.StatusLost {
+			response.Error(w, http.StatusBadRequest, "courseName can only be set when a disc is lost")
+			return
+		}
+		courseName, err := validation.ValidateString(req.CourseName,
+			validation.StringRules{Field: "courseName"}.Trimmed().Max(200),
+		)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+			return
+		}
+		input.CourseName = courseName
+	}
+
+	if req.RecoveredAt != nil {
+		loc := time.UTC
+		if meta.Timezone != nil {
+			loc = meta.Timezone
+		}
+		recoveredAt, err := time.ParseInLocation("2006-01-02", *req.RecoveredAt, loc)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid recoveredAt, expected YYYY-MM-DD")
+			return
+		}
+		if recoveredAt.After(time.Now()) {
+			response.Error(w, http.StatusBadRequest, "recoveredAt cannot be in the future")
+			return
+		}
+		input.RecoveredAt = &recoveredAt
+	}
+
+	note, err := validation.ValidateString(req.Note,
+		validation.StringRules{Field: "note"}.Trimmed().Max(500),
+	)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+		return
+	}
+	input.Note = note
+
+	d, err := h.discService.UpdateDiscStatus(r.Context(), meta.User.ID, discID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrStatusUnchanged),
+			errors.Is(err, services.ErrUnknownCourse),
+			errors.Is(err, services.ErrDiscNotLost),
+			errors.Is(err, services.ErrInvalidRecoveryDate),
+			errors.Is(err, services.ErrBagRequired):
+			response.Error(w, http.StatusBadRequest, err.Error())
+		default:
+			writeAccessError(w, err, "disc or bag not found", "not authorized to update this disc", "failed to update disc status")
+		}
 		return
 	}
 
 	_ = response.Success(w, d)
 }
 
-// DELETE /discs/{id}
-func (h *DiscHandler) DeleteDisc(w http.ResponseWriter, r *http.Request) {
+// GET /discs/shelf
+func (h *DiscHandler) GetShelf(w http.ResponseWriter, r *http.Request) {
 	meta := requestmeta.GetMeta(r.Context())
 
-	var userID *string
+	userID := h.cfg.OwnerUserID
 	if meta != nil && meta.User != nil {
-		userID = &meta.User.ID
+		userID = meta.User.ID
 	}
 
-	discIDStr := r.PathValue("id")
-	discID, err := validation.ValidateObjectID(discIDStr, "disc id")
+	shelf, err := h.discService.GetShelf(r.Context(), userID, callerID(r))
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch shelf")
+		return
+	}
+
+	_ = response.Success(w, shelf)
+}
+
+// GET /discs/{id}/history
+func (h *DiscHandler) GetDiscHistory(w http.ResponseWriter, r *http.Request) {
+	discID, err := validation.ValidateObjectID(r.PathValue("id"), "disc id")
 	if err != nil {
 		response.Error(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	ctx := r.Context()
+	history, err := h.discService.GetDiscHistory(r.Context(), callerID(r), discID)
+	if err != nil {
+		writeAccessError(w, err, "disc not found", "not authorized to view this disc", "failed to fetch disc history")
+		return
+	}
 
-	if userID == nil {
-		_, err := h.discService.DeleteDisc(ctx, userID, discID)
-		if err != nil {
-			response.Error(w, http.StatusInternalServerError, "failed to delete disc")
-			return
+	_ = response.Success(w, history)
+}
+
+// POST /discs/{id}/photos (multipart, field "photo")
+func (h *DiscHandler) UploadPhoto(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to upload photos")
+		return
+	}
+
+	discID, err := validation.ValidateObjectID(r.PathValue("id"), "disc id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Leave room for the multipart framing around the file.
+	r.Body = http.MaxBytesReader(w, r.Body, disc.MaxPhotoBytes+1<<20)
+	if err := r.ParseMultipartForm(disc.MaxPhotoBytes); err != nil {
+		response.Error(w, http.StatusBadRequest, "failed to parse form; photos must be under 10 MB")
+		return
+	}
+
+	file, header, err := r.FormFile("photo")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "photo is required")
+		return
+	}
+	defer file.Close()
+
+	if header.Size > disc.MaxPhotoBytes {
+		response.Error(w, http.StatusBadRequest, "photos must be under 10 MB")
+		return
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to read photo")
+		return
+	}
+
+	photo, err := h.discService.AddPhoto(r.Context(), meta.User.ID, discID, data)
+	if err != nil {
+		switch {
+		case errors.Is(err, disc.ErrInvalidImage),
+			errors.Is(err, services.ErrTooManyPhotos):
+			response.Error(w, http.StatusBadRequest, err.Error())
+		default:
+			writeAccessError(w, err, "disc not found", "not authorized to update this disc", "failed to upload photo")
 		}
+		return
+	}
+
+	_ = response.Success(w, photo)
+}
+
+// GET /discs/{id}/photos/{photoId}?size=thumb
+func (h *DiscHandler) GetPhoto(w http.ResponseWriter, r *http.Request) {
+	discID, err := validation.ValidateObjectID(r.PathValue("id"), "disc id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	photoID, err := validation.ValidateObjectID(r.PathValue("photoId"), "photo id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-		_ = response.Success(w, map[string]string{"message": "disc deleted successfully"})
+	size, err := validation.ValidateString(r.URL.Query().Get("size"),
+		validation.StringRules{Field: "size"}.Trimmed().In("full", "thumb"),
+	)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
 		return
 	}
 
-	existingDisc, err := h.discService.GetDiscByID(ctx, discID)
+	body, contentType, err := h.discService.GetPhoto(r.Context(), callerID(r), discID, photoID, size == "thumb")
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "failed to fetch disc")
+		writeAccessError(w, err, "photo not found", "not authorized to view this disc", "failed to fetch photo")
 		return
 	}
-	if existingDisc == nil {
-		response.Error(w, http.StatusNotFound, "disc not found")
+	defer body.Close()
+
+	// Blob keys are never reused, so the bytes behind a URL never change.
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+	_, _ = io.Copy(w, body)
+}
+
+// DELETE /discs/{id}/photos/{photoId}
+func (h *DiscHandler) DeletePhoto(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to delete photos")
 		return
 	}
 
-	if existingDisc.UserID != *userID {
-		response.Error(w, http.StatusForbidden, "not authorized to delete this disc")
+	discID, err := validation.ValidateObjectID(r.PathValue("id"), "disc id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	_, err = h.discService.DeleteDisc(ctx, userID, discID)
+	photoID, err := validation.ValidateObjectID(r.PathValue("photoId"), "photo id")
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "failed to delete disc")
+		response.Error(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	_ = response.Success(w, map[string]string{"message": "disc deleted successfully"})
+	if err := h.discService.DeletePhoto(r.Context(), meta.User.ID, discID, photoID); err != nil {
+		writeAccessError(w, err, "photo not found", "not authorized to update this disc", "failed to delete photo")
+		return
+	}
+
+	_ = response.Success(w, map[string]string{"message": "photo deleted successfully"})
 }
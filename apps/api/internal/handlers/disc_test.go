@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUpdateDiscRequestAdjustedFlight(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantSet   bool
+		wantNil   bool
+		wantSpeed float64
+	}{
+		{"omitted", `{"notes":"beat in"}`, false, true, 0},
+		{"null", `{"adjustedFlight":null}`, true, true, 0},
+		{"numbers", `{"adjustedFlight":{"speed":12,"glide":5,"turn":-2,"fade":2}}`, true, false, 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req updateDiscRequest
+			if err := json.Unmarshal([]byte(tt.body), &req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if req.AdjustedFlight.Set != tt.wantSet {
+				t.Errorf("Set = %v, want %v", req.AdjustedFlight.Set, tt.wantSet)
+			}
+			if (req.AdjustedFlight.Flight == nil) != tt.wantNil {
+				t.Fatalf("Flight = %v, want nil: %v", req.AdjustedFlight.Flight, tt.wantNil)
+			}
+			if !tt.wantNil && req.AdjustedFlight.Flight.Speed != tt.wantSpeed {
+				t.Errorf("Speed = %v, want %v", req.AdjustedFlight.Flight.Speed, tt.wantSpeed)
+			}
+		})
+	}
+}
@@ -0,0 +1,529 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Tidwell32/zack/apps/api/internal/config"
+	"github.com/Tidwell32/zack/apps/api/internal/league"
+	"github.com/Tidwell32/zack/apps/api/internal/requestmeta"
+	"github.com/Tidwell32/zack/apps/api/internal/services"
+	"github.com/Tidwell32/zack/apps/api/pkg/response"
+	"github.com/Tidwell32/zack/apps/api/pkg/validation"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type LeagueHandler struct {
+	cfg           *config.Config
+	leagueService *services.LeagueService
+}
+
+func NewLeagueHandler(cfg *config.Config, leagueService *services.LeagueService) *LeagueHandler {
+	return &LeagueHandler{
+		cfg:           cfg,
+		leagueService: leagueService,
+	}
+}
+
+type leagueRequest struct {
+	Name            *string   `json:"name"`
+	PointsTable     []float64 `json:"pointsTable"`
+	PayoutTable     []float64 `json:"payoutTable"`
+	HandicapRounds  *int      `json:"handicapRounds"`
+	HandicapPercent *float64  `json:"handicapPercent"`
+}
+
+func (req leagueRequest) validate() (string, bool) {
+	for _, p := range req.PointsTable {
+		if p < 0 {
+			return "pointsTable cannot contain negative points", false
+		}
+	}
+
+	total := 0.0
+	for _, p := range req.PayoutTable {
+		if p < 0 {
+			return "payoutTable cannot contain negative shares", false
+		}
+		total += p
+	}
+	if total > 1.0001 {
+		return "payoutTable shares cannot add up to more than 1", false
+	}
+
+	if req.HandicapRounds != nil {
+		if _, err := validation.ValidateInt(*req.HandicapRounds, validation.IntRules{Field: "handicapRounds"}.MinValue(1).MaxValue(100)); err != nil {
+			return err.Error(), false
+		}
+	}
+	if req.HandicapPercent != nil && (*req.HandicapPercent < 0 || *req.HandicapPercent > 1) {
+		return "handicapPercent must be between 0 and 1", false
+	}
+
+	return "", true
+}
+
+// POST /leagues
+func (h *LeagueHandler) CreateLeague(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to manage leagues")
+		return
+	}
+
+	var req leagueRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	nameValue := ""
+	if req.Name != nil {
+		nameValue = *req.Name
+	}
+	name, err := validation.ValidateString(nameValue,
+		validation.StringRules{Field: "name"}.RequiredField().Trimmed().Min(2).Max(50),
+	)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+		return
+	}
+
+	if msg, ok := req.validate(); !ok {
+		response.Error(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	input := league.CreateLeagueInput{
+		Name:        name,
+		PointsTable: req.PointsTable,
+		PayoutTable: req.PayoutTable,
+	}
+	if req.HandicapRounds != nil {
+		input.HandicapRounds = *req.HandicapRounds
+	}
+	if req.HandicapPercent != nil {
+		input.HandicapPercent = *req.HandicapPercent
+	}
+
+	l, err := h.leagueService.CreateLeague(r.Context(), meta.User.ID, input)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to create league")
+		return
+	}
+
+	_ = response.Success(w, l)
+}
+
+// GET /leagues
+func (h *LeagueHandler) GetLeagues(w http.ResponseWriter, r *http.Request) {
+	leagues, err := h.leagueService.GetLeaguesForUser(r.Context(), h.userID(r))
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch leagues")
+		return
+	}
+
+	_ = response.Success(w, leagues)
+}
+
+// GET /leagues/{id}
+func (h *LeagueHandler) GetLeague(w http.ResponseWriter, r *http.Request) {
+	leagueID, err := validation.ValidateObjectID(r.PathValue("id"), "league id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	l, err := h.leagueService.GetLeague(r.Context(), h.userID(r), leagueID)
+	if err != nil {
+		writeLeagueError(w, err, "league not found", "failed to fetch league")
+		return
+	}
+
+	_ = response.Success(w, l)
+}
+
+// PATCH /leagues/{id}
+func (h *LeagueHandler) UpdateLeague(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to manage leagues")
+		return
+	}
+
+	leagueID, err := validation.ValidateObjectID(r.PathValue("id"), "league id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req leagueRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if msg, ok := req.validate(); !ok {
+		response.Error(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	input := league.UpdateLeagueInput{
+		PointsTable:     req.PointsTable,
+		PayoutTable:     req.PayoutTable,
+		HandicapRounds:  req.HandicapRounds,
+		HandicapPercent: req.HandicapPercent,
+	}
+	if req.Name != nil {
+		name, err := validation.ValidateString(*req.Name,
+			validation.StringRules{Field: "name"}.RequiredField().Trimmed().Min(2).Max(50),
+		)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+			return
+		}
+		input.Name = &name
+	}
+
+	l, err := h.leagueService.UpdateLeague(r.Context(), meta.User.ID, leagueID, input)
+	if err != nil {
+		writeLeagueError(w, err, "league not found", "failed to update league")
+		return
+	}
+
+	_ = response.Success(w, l)
+}
+
+type createSeasonRequest struct {
+	Name      string `json:"name"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+}
+
+// POST /leagues/{id}/seasons
+func (h *LeagueHandler) CreateSeason(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to manage leagues")
+		return
+	}
+
+	leagueID, err := validation.ValidateObjectID(r.PathValue("id"), "league id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req createSeasonRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	name, err := validation.ValidateString(req.Name,
+		validation.StringRules{Field: "name"}.RequiredField().Trimmed().Min(2).Max(50),
+	)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+		return
+	}
+
+	loc := h.location(r)
+
+	startDate, err := time.ParseInLocation("2006-01-02", req.StartDate, loc)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid startDate, expected YYYY-MM-DD")
+		return
+	}
+
+	endDate, err := time.ParseInLocation("2006-01-02", req.EndDate, loc)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid endDate, expected YYYY-MM-DD")
+		return
+	}
+
+	if endDate.Before(startDate) {
+		response.Error(w, http.StatusBadRequest, "endDate cannot be before startDate")
+		return
+	}
+
+	season, err := h.leagueService.CreateSeason(r.Context(), meta.User.ID, leagueID, league.CreateSeasonInput{
+		Name:      name,
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+	if err != nil {
+		writeLeagueError(w, err, "league not found", "failed to create season")
+		return
+	}
+
+	_ = response.Success(w, season)
+}
+
+// GET /leagues/{id}/seasons
+func (h *LeagueHandler) GetSeasons(w http.ResponseWriter, r *http.Request) {
+	leagueID, err := validation.ValidateObjectID(r.PathValue("id"), "league id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	seasons, err := h.leagueService.GetSeasonsForLeague(r.Context(), h.userID(r), leagueID)
+	if err != nil {
+		writeLeagueError(w, err, "league not found", "failed to fetch seasons")
+		return
+	}
+
+	_ = response.Success(w, seasons)
+}
+
+type createEventRequest struct {
+	Name     string        `json:"name"`
+	Date     string        `json:"date"`
+	Format   string        `json:"format"`
+	EntryFee float64       `json:"entryFee"`
+	Teams    []league.Team `json:"teams"`
+}
+
+// POST /league-seasons/{id}/events
+func (h *LeagueHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to manage leagues")
+		return
+	}
+
+	seasonID, err := validation.ValidateObjectID(r.PathValue("id"), "season id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req createEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	name, err := validation.ValidateString(req.Name,
+		validation.StringRules{Field: "name"}.RequiredField().Trimmed().Min(2).Max(50),
+	)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+		return
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", req.Date, h.location(r))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid date, expected YYYY-MM-DD")
+		return
+	}
+
+	if req.Format == "" {
+		req.Format = league.FormatSingles
+	}
+	format, err := validation.ValidateString(req.Format,
+		validation.StringRules{Field: "format"}.Trimmed().In(league.FormatSingles, league.FormatDoubles),
+	)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+		return
+	}
+
+	if req.EntryFee < 0 {
+		response.Error(w, http.StatusBadRequest, "entryFee cannot be negative")
+		return
+	}
+
+	teams, msg, ok := validateTeams(req.Teams)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	event, err := h.leagueService.CreateEvent(r.Context(), meta.User.ID, seasonID, league.CreateEventInput{
+		Name:     name,
+		Date:     date,
+		Format:   format,
+		EntryFee: req.EntryFee,
+		Teams:    teams,
+	})
+	if err != nil {
+		writeLeagueError(w, err, "season not found", "failed to create event")
+		return
+	}
+
+	_ = response.Success(w, event)
+}
+
+// GET /league-seasons/{id}/events
+func (h *LeagueHandler) GetEvents(w http.ResponseWriter, r *http.Request) {
+	seasonID, err := validation.ValidateObjectID(r.PathValue("id"), "season id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	events, err := h.leagueService.GetEventsForSeason(r.Context(), h.userID(r), seasonID)
+	if err != nil {
+		writeLeagueError(w, err, "season not found", "failed to fetch events")
+		return
+	}
+
+	_ = response.Success(w, events)
+}
+
+// GET /league-seasons/{id}/standings
+func (h *LeagueHandler) GetStandings(w http.ResponseWriter, r *http.Request) {
+	seasonID, err := validation.ValidateObjectID(r.PathValue("id"), "season id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	standings, err := h.leagueService.GetStandings(r.Context(), h.userID(r), seasonID)
+	if err != nil {
+		writeLeagueError(w, err, "season not found", "failed to fetch standings")
+		return
+	}
+
+	_ = response.Success(w, standings)
+}
+
+// GET /league-events/{id}
+func (h *LeagueHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
+	eventID, err := validation.ValidateObjectID(r.PathValue("id"), "event id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	event, err := h.leagueService.GetEvent(r.Context(), h.userID(r), eventID)
+	if err != nil {
+		writeLeagueError(w, err, "event not found", "failed to fetch event")
+		return
+	}
+
+	_ = response.Success(w, event)
+}
+
+type scoreEventRequest struct {
+	RoundID *string       `json:"roundId"`
+	Teams   []league.Team `json:"teams"`
+}
+
+// PUT /league-events/{id}/round
+// Links a round to the event and scores it. Omit roundId to rescore.
+func (h *LeagueHandler) ScoreEvent(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to manage leagues")
+		return
+	}
+
+	eventID, err := validation.ValidateObjectID(r.PathValue("id"), "event id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req scoreEventRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	var roundID *bson.ObjectID
+	if req.RoundID != nil {
+		oid, err := validation.ValidateObjectID(*req.RoundID, "round id")
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		roundID = &oid
+	}
+
+	teams, msg, ok := validateTeams(req.Teams)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	event, err := h.leagueService.ScoreEvent(r.Context(), meta.User.ID, eventID, roundID, teams)
+	if err != nil {
+		if errors.Is(err, services.ErrEventHasNoRound) {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeLeagueError(w, err, "event or round not found", "failed to score event")
+		return
+	}
+
+	_ = response.Success(w, event)
+}
+
+func (h *LeagueHandler) userID(r *http.Request) string {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta != nil && meta.User != nil {
+		return meta.User.ID
+	}
+	return h.cfg.OwnerUserID
+}
+
+func (h *LeagueHandler) location(r *http.Request) *time.Location {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta != nil && meta.Timezone != nil {
+		return meta.Timezone
+	}
+	return time.UTC
+}
+
+func validateTeams(teams []league.Team) ([]league.Team, string, bool) {
+	if teams == nil {
+		return nil, "", true
+	}
+
+	validated := make([]league.Team, 0, len(teams))
+	for _, t := range teams {
+		name, err := validation.ValidateString(t.Name,
+			validation.StringRules{Field: "team name"}.RequiredField().Trimmed().Max(100),
+		)
+		if err != nil {
+			return nil, validation.ToHTTPMessage(err), false
+		}
+
+		if len(t.Players) == 0 {
+			return nil, "each team needs at least one player", false
+		}
+		players := make([]string, 0, len(t.Players))
+		for _, p := range t.Players {
+			player, err := validation.ValidateString(p,
+				validation.StringRules{Field: "team player"}.RequiredField().Trimmed().Max(50),
+			)
+			if err != nil {
+				return nil, validation.ToHTTPMessage(err), false
+			}
+			players = append(players, player)
+		}
+
+		validated = append(validated, league.Team{Name: name, Players: players})
+	}
+
+	return validated, "", true
+}
+
+func writeLeagueError(w http.ResponseWriter, err error, notFound, fallback string) {
+	if errors.Is(err, services.ErrNotFound) {
+		response.Error(w, http.StatusNotFound, notFound)
+		return
+	}
+	response.Error(w, http.StatusInternalServerError, fallback)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/Tidwell32/zack/apps/api/internal/config"
+	"github.com/Tidwell32/zack/apps/api/internal/requestmeta"
+	"github.com/Tidwell32/zack/apps/api/internal/services"
+	"github.com/Tidwell32/zack/apps/api/internal/settings"
+	"github.com/Tidwell32/zack/apps/api/pkg/response"
+)
+
+type SettingsHandler struct {
+	cfg             *config.Config
+	settingsService *services.SettingsService
+}
+
+func NewSettingsHandler(cfg *config.Config, settingsService *services.SettingsService) *SettingsHandler {
+	return &SettingsHandler{
+		cfg:             cfg,
+		settingsService: settingsService,
+	}
+}
+
+// GET /settings
+func (h *SettingsHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to view settings")
+		return
+	}
+
+	us, err := h.settingsService.GetSettings(r.Context(), meta.User.ID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch settings")
+		return
+	}
+
+	_ = response.Success(w, us)
+}
+
+type suggestPreferencesRequest struct {
+	PreferredBrands  []string `json:"preferredBrands"`
+	ExcludedBrands   []string `json:"excludedBrands"`
+	OwnedMoldPenalty float64  `json:"ownedMoldPenalty"`
+}
+
+func (req suggestPreferencesRequest) validate() (settings.SuggestPreferences, error) {
+	// Brands are matched by slug, so "Axiom Discs" and "axiom-discs" are
+	// the same preference.
+	preferred := uniqueSlugs(req.PreferredBrands)
+	excluded := uniqueSlugs(req.ExcludedBrands)
+
+	if len(preferred) > settings.MaxBrandPreferences || len(excluded) > settings.MaxBrandPreferences {
+		return settings.SuggestPreferences{}, fmt.Errorf("at most %d brands can be preferred or excluded", settings.MaxBrandPreferences)
+	}
+	if req.OwnedMoldPenalty < 0 || req.OwnedMoldPenalty > settings.MaxOwnedMoldPenalty {
+		return settings.SuggestPreferences{}, fmt.Errorf("ownedMoldPenalty must be between 0 and %d", settings.MaxOwnedMoldPenalty)
+	}
+
+	return settings.SuggestPreferences{
+		PreferredBrands:  preferred,
+		ExcludedBrands:   excluded,
+		OwnedMoldPenalty: req.OwnedMoldPenalty,
+	}, nil
+}
+
+// PUT /settings/suggest
+func (h *SettingsHandler) UpdateSuggestPreferences(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to update settings")
+		return
+	}
+
+	var req suggestPreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	prefs, err := req.validate()
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	us, err := h.settingsService.UpdateSuggestPreferences(r.Context(), meta.User.ID, prefs)
+	if err != nil {
+		if errors.Is(err, settings.ErrBrandPreferredAndExcluded) {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "failed to update settings")
+		return
+	}
+
+	_ = response.Success(w, us)
+}
+
+// uniqueSlugs normalizes brand names to slugs, keeping the first
+// occurrence of each so list order is preserved.
+func uniqueSlugs(values []string) []string {
+	slugs := []string{}
+	for _, slug := range slugList(values) {
+		if !slices.Contains(slugs, slug) {
+			slugs = append(slugs, slug)
+		}
+	}
+	return slugs
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
@@ -9,7 +11,9 @@ import (
 	"github.com/Tidwell32/zack/apps/api/internal/config"
 	"github.com/Tidwell32/zack/apps/api/internal/requestmeta"
 	"github.com/Tidwell32/zack/apps/api/internal/services"
+	"github.com/Tidwell32/zack/apps/api/internal/udisc"
 	"github.com/Tidwell32/zack/apps/api/pkg/response"
+	"github.com/Tidwell32/zack/apps/api/pkg/validation"
 )
 
 type UDiscHandler struct {
@@ -164,3 +168,588 @@ func (h *UDiscHandler) GetCourses(w http.ResponseWriter, r *http.Request) {
 
 	_ = response.Success(w, courses)
 }
+
+type createRoundRequest struct {
+	CourseName string   `json:"courseName"`
+	LayoutName string   `json:"layoutName"`
+	Pars       []int    `json:"pars"`
+	Players    []string `json:"players"`
+	StartTime  *string  `json:"startTime"`
+}
+
+// POST /udisc/rounds
+// Pars may be omitted to reuse the pars from the last round on that layout.
+func (h *UDiscHandler) CreateRound(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to record rounds")
+		return
+	}
+
+	var req createRoundRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	courseName, err := validation.ValidateString(req.CourseName,
+		validation.StringRules{Field: "courseName"}.RequiredField().Trimmed().Max(100),
+	)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+		return
+	}
+
+	layoutName, err := validation.ValidateString(req.LayoutName,
+		validation.StringRules{Field: "layoutName"}.RequiredField().Trimmed().Max(100),
+	)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+		return
+	}
+
+	if len(req.Pars) > 36 {
+		response.Error(w, http.StatusBadRequest, "pars must have at most 36 holes")
+		return
+	}
+	for _, par := range req.Pars {
+		if _, err := validation.ValidateInt(par, validation.IntRules{Field: "par"}.MinValue(1).MaxValue(10)); err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if len(req.Players) == 0 {
+		response.Error(w, http.StatusBadRequest, "at least one player is required")
+		return
+	}
+	players := make([]string, 0, len(req.Players))
+	for _, p := range req.Players {
+		name, err := validation.ValidateString(p,
+			validation.StringRules{Field: "player"}.RequiredField().Trimmed().Max(50),
+		)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+			return
+		}
+		players = append(players, name)
+	}
+
+	input := udisc.CreateRoundInput{
+		CourseName:  courseName,
+		LayoutName:  layoutName,
+		Pars:        req.Pars,
+		PlayerNames: players,
+	}
+
+	if req.StartTime != nil {
+		startTime, err := time.Parse(time.RFC3339, *req.StartTime)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid startTime, expected RFC3339")
+			return
+		}
+		input.StartTime = &startTime
+	}
+
+	round, err := h.udiscService.CreateRound(r.Context(), meta.User.ID, input)
+	if err != nil {
+		writeRoundError(w, err, "failed to create round")
+		return
+	}
+
+	_ = response.Success(w, round)
+}
+
+// GET /udisc/rounds/{id}
+func (h *UDiscHandler) GetRound(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+
+	userID := h.cfg.OwnerUserID
+	if meta != nil && meta.User != nil {
+		userID = meta.User.ID
+	}
+
+	roundID, err := validation.ValidateObjectID(r.PathValue("id"), "round id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	round, err := h.udiscService.GetRound(r.Context(), userID, roundID)
+	if err != nil {
+		writeRoundError(w, err, "failed to fetch round")
+		return
+	}
+
+	_ = response.Success(w, round)
+}
+
+type addRoundPlayerRequest struct {
+	PlayerName string `json:"playerName"`
+}
+
+// POST /udisc/rounds/{id}/players
+func (h *UDiscHandler) AddPlayerToRound(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to record rounds")
+		return
+	}
+
+	roundID, err := validation.ValidateObjectID(r.PathValue("id"), "round id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req addRoundPlayerRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	name, err := validation.ValidateString(req.PlayerName,
+		validation.StringRules{Field: "playerName"}.RequiredField().Trimmed().Max(50),
+	)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+		return
+	}
+
+	round, err := h.udiscService.AddPlayerToRound(r.Context(), meta.User.ID, roundID, name)
+	if err != nil {
+		writeRoundError(w, err, "failed to add player")
+		return
+	}
+
+	_ = response.Success(w, round)
+}
+
+type recordScoreRequest struct {
+	PlayerName string `json:"playerName"`
+	Hole       int    `json:"hole"`
+	Score      int    `json:"score"`
+}
+
+// PATCH /udisc/rounds/{id}/scores
+func (h *UDiscHandler) RecordScore(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to record rounds")
+		return
+	}
+
+	roundID, err := validation.ValidateObjectID(r.PathValue("id"), "round id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req recordScoreRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	name, err := validation.ValidateString(req.PlayerName,
+		validation.StringRules{Field: "playerName"}.RequiredField().Trimmed(),
+	)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+		return
+	}
+
+	score, err := validation.ValidateInt(req.Score, validation.IntRules{Field: "score"}.MinValue(1).MaxValue(20))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	round, err := h.udiscService.RecordScore(r.Context(), meta.User.ID, roundID, udisc.RecordScoreInput{
+		PlayerName: name,
+		Hole:       req.Hole,
+		Score:      score,
+	})
+	if err != nil {
+		writeRoundError(w, err, "failed to record score")
+		return
+	}
+
+	_ = response.Success(w, round)
+}
+
+// POST /udisc/rounds/{id}/finalize
+func (h *UDiscHandler) FinalizeRound(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to record rounds")
+		return
+	}
+
+	roundID, err := validation.ValidateObjectID(r.PathValue("id"), "round id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	round, err := h.udiscService.FinalizeRound(r.Context(), meta.User.ID, roundID)
+	if err != nil {
+		writeRoundError(w, err, "failed to finalize round")
+		return
+	}
+
+	_ = response.Success(w, round)
+}
+
+type updateRoundRequest struct {
+	CourseName *string `json:"courseName"`
+	LayoutName *string `json:"layoutName"`
+	Pars       []int   `json:"pars"`
+}
+
+// PATCH /udisc/rounds/{id}
+func (h *UDiscHandler) UpdateRound(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to edit rounds")
+		return
+	}
+
+	roundID, err := validation.ValidateObjectID(r.PathValue("id"), "round id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req updateRoundRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	var input udisc.UpdateRoundInput
+
+	if req.CourseName != nil {
+		courseName, err := validation.ValidateString(*req.CourseName,
+			validation.StringRules{Field: "courseName"}.RequiredField().Trimmed().Max(100),
+		)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+			return
+		}
+		input.CourseName = &courseName
+	}
+
+	if req.LayoutName != nil {
+		layoutName, err := validation.ValidateString(*req.LayoutName,
+			validation.StringRules{Field: "layoutName"}.RequiredField().Trimmed().Max(100),
+		)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+			return
+		}
+		input.LayoutName = &layoutName
+	}
+
+	if req.Pars != nil {
+		if len(req.Pars) == 0 || len(req.Pars) > 36 {
+			response.Error(w, http.StatusBadRequest, "pars must have between 1 and 36 holes")
+			return
+		}
+		for _, par := range req.Pars {
+			if _, err := validation.ValidateInt(par, validation.IntRules{Field: "par"}.MinValue(1).MaxValue(10)); err != nil {
+				response.Error(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		input.Pars = req.Pars
+	}
+
+	round, err := h.udiscService.UpdateRound(r.Context(), meta.User.ID, roundID, input)
+	if err != nil {
+		writeRoundError(w, err, "failed to update round")
+		return
+	}
+
+	_ = response.Success(w, round)
+}
+
+// DELETE /udisc/rounds/{id}
+func (h *UDiscHandler) DeleteRound(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to edit rounds")
+		return
+	}
+
+	roundID, err := validation.ValidateObjectID(r.PathValue("id"), "round id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.udiscService.DeleteRound(r.Context(), meta.User.ID, roundID); err != nil {
+		writeRoundError(w, err, "failed to delete round")
+		return
+	}
+
+	_ = response.Success(w, map[string]string{"message": "round deleted successfully"})
+}
+
+type correctPlayerRequest struct {
+	PlayerName *string `json:"playerName"`
+	Scores     []int   `json:"scores"`
+}
+
+// PATCH /udisc/rounds/{id}/players/{name}
+func (h *UDiscHandler) CorrectPlayer(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to edit rounds")
+		return
+	}
+
+	roundID, err := validation.ValidateObjectID(r.PathValue("id"), "round id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	playerName := strings.TrimSpace(r.PathValue("name"))
+	if playerName == "" {
+		response.Error(w, http.StatusBadRequest, "player name is required")
+		return
+	}
+
+	var req correctPlayerRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	var input udisc.CorrectPlayerInput
+
+	if req.PlayerName != nil {
+		name, err := validation.ValidateString(*req.PlayerName,
+			validation.StringRules{Field: "playerName"}.RequiredField().Trimmed().Max(50),
+		)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+			return
+		}
+		input.PlayerName = &name
+	}
+
+	if req.Scores != nil {
+		for _, score := range req.Scores {
+			if _, err := validation.ValidateInt(score, validation.IntRules{Field: "score"}.MinValue(1).MaxValue(20)); err != nil {
+				response.Error(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		input.Scores = req.Scores
+	}
+
+	round, err := h.udiscService.CorrectPlayer(r.Context(), meta.User.ID, roundID, playerName, input)
+	if err != nil {
+		writeRoundError(w, err, "failed to correct player")
+		return
+	}
+
+	_ = response.Success(w, round)
+}
+
+// GET /udisc/players/aliases
+func (h *UDiscHandler) GetPlayerAliases(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+
+	userID := h.cfg.OwnerUserID
+	if meta != nil && meta.User != nil {
+		userID = meta.User.ID
+	}
+
+	aliases, err := h.udiscService.GetPlayerAliases(r.Context(), userID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch player aliases")
+		return
+	}
+
+	_ = response.Success(w, aliases)
+}
+
+type mergePlayersRequest struct {
+	Alias         string `json:"alias"`
+	CanonicalName string `json:"canonicalName"`
+}
+
+// POST /udisc/players/aliases
+// Reports every round played as alias under canonicalName instead.
+func (h *UDiscHandler) MergePlayers(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to merge players")
+		return
+	}
+
+	var req mergePlayersRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	alias, err := validation.ValidateString(req.Alias,
+		validation.StringRules{Field: "alias"}.RequiredField().Trimmed().Max(50),
+	)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+		return
+	}
+
+	canonicalName, err := validation.ValidateString(req.CanonicalName,
+		validation.StringRules{Field: "canonicalName"}.RequiredField().Trimmed().Max(50),
+	)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, validation.ToHTTPMessage(err))
+		return
+	}
+
+	pa, err := h.udiscService.MergePlayers(r.Context(), meta.User.ID, alias, canonicalName)
+	if err != nil {
+		writeRoundError(w, err, "failed to merge players")
+		return
+	}
+
+	_ = response.Success(w, pa)
+}
+
+// DELETE /udisc/players/aliases/{alias}
+func (h *UDiscHandler) DeletePlayerAlias(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+	if meta == nil || meta.User == nil {
+		response.Error(w, http.StatusUnauthorized, "login required to merge players")
+		return
+	}
+
+	alias := strings.TrimSpace(r.PathValue("alias"))
+	if alias == "" {
+		response.Error(w, http.StatusBadRequest, "alias is required")
+		return
+	}
+
+	if err := h.udiscService.DeletePlayerAlias(r.Context(), meta.User.ID, alias); err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "alias not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "failed to delete alias")
+		return
+	}
+
+	_ = response.Success(w, map[string]string{"message": "alias deleted successfully"})
+}
+
+// GET /udisc/players/{name}/achievements
+func (h *UDiscHandler) GetPlayerAchievements(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+
+	userID := h.cfg.OwnerUserID
+	if meta != nil && meta.User != nil {
+		userID = meta.User.ID
+	}
+
+	playerName := strings.TrimSpace(r.PathValue("name"))
+	if playerName == "" {
+		response.Error(w, http.StatusBadRequest, "player name is required")
+		return
+	}
+
+	achievements, err := h.udiscService.GetPlayerAchievements(r.Context(), userID, playerName)
+	if err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "player not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "failed to fetch achievements")
+		return
+	}
+
+	_ = response.Success(w, achievements)
+}
+
+// GET /udisc/predict
+// Query params:
+//   - layout=normalizedCourseId or LayoutName (required)
+//   - player=Name                            (required)
+//   - course=CourseName                      (optional, narrows a layout name)
+func (h *UDiscHandler) PredictScore(w http.ResponseWriter, r *http.Request) {
+	meta := requestmeta.GetMeta(r.Context())
+
+	userID := h.cfg.OwnerUserID
+	if meta != nil && meta.User != nil {
+		userID = meta.User.ID
+	}
+
+	q := r.URL.Query()
+
+	layout := strings.TrimSpace(q.Get("layout"))
+	if layout == "" {
+		response.Error(w, http.StatusBadRequest, "query parameter 'layout' is required")
+		return
+	}
+
+	player := strings.TrimSpace(q.Get("player"))
+	if player == "" {
+		response.Error(w, http.StatusBadRequest, "query parameter 'player' is required")
+		return
+	}
+
+	course := strings.TrimSpace(q.Get("course"))
+
+	prediction, err := h.udiscService.PredictScore(r.Context(), userID, layout, course, player)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUnknownLayout):
+			response.Error(w, http.StatusNotFound, "layout not found")
+		case errors.Is(err, services.ErrNoPlayerHistory):
+			response.Error(w, http.StatusNotFound, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, "failed to predict score")
+		}
+		return
+	}
+
+	_ = response.Success(w, prediction)
+}
+
+func writeRoundError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "round not found")
+	case errors.Is(err, services.ErrPlayerNotInRound):
+		response.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrUnknownLayout),
+		errors.Is(err, services.ErrDuplicatePlayer),
+		errors.Is(err, services.ErrInvalidHole),
+		errors.Is(err, services.ErrInvalidScores),
+		errors.Is(err, services.ErrInvalidAlias):
+		response.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrRoundNotManual),
+		errors.Is(err, services.ErrRoundFinalized),
+		errors.Is(err, services.ErrImportedRoundKey):
+		response.Error(w, http.StatusConflict, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,119 @@
+package league
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	FormatSingles = "singles"
+	FormatDoubles = "doubles"
+)
+
+type League struct {
+	ID     bson.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID string        `bson:"userId" json:"userId"`
+	Name   string        `bson:"name" json:"name"`
+
+	// Points awarded by finishing place, index 0 is first
+	PointsTable []float64 `bson:"pointsTable" json:"pointsTable"`
+	// Share of the event pot paid by finishing place, index 0 is first
+	PayoutTable []float64 `bson:"payoutTable" json:"payoutTable"`
+
+	// Handicap = HandicapPercent * average over par of the last HandicapRounds
+	HandicapRounds  int     `bson:"handicapRounds" json:"handicapRounds"`
+	HandicapPercent float64 `bson:"handicapPercent" json:"handicapPercent"`
+
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+type Season struct {
+	ID        bson.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID    string        `bson:"userId" json:"userId"`
+	LeagueID  bson.ObjectID `bson:"leagueId" json:"leagueId"`
+	Name      string        `bson:"name" json:"name"`
+	StartDate time.Time     `bson:"startDate" json:"startDate"`
+	EndDate   time.Time     `bson:"endDate" json:"endDate"`
+	CreatedAt time.Time     `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time     `bson:"updatedAt" json:"updatedAt"`
+}
+
+type Event struct {
+	ID       bson.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID   string        `bson:"userId" json:"userId"`
+	LeagueID bson.ObjectID `bson:"leagueId" json:"leagueId"`
+	SeasonID bson.ObjectID `bson:"seasonId" json:"seasonId"`
+
+	Name     string    `bson:"name" json:"name"`
+	Date     time.Time `bson:"date" json:"date"`
+	Format   string    `bson:"format" json:"format"`
+	EntryFee float64   `bson:"entryFee" json:"entryFee"`
+
+	// Doubles teams, keyed by the name the team scored under on the card
+	Teams []Team `bson:"teams,omitempty" json:"teams,omitempty"`
+
+	RoundID  *bson.ObjectID `bson:"roundId,omitempty" json:"roundId,omitempty"`
+	Results  []EventResult  `bson:"results" json:"results"`
+	ScoredAt *time.Time     `bson:"scoredAt,omitempty" json:"scoredAt,omitempty"`
+
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+type Team struct {
+	Name    string   `bson:"name" json:"name"`
+	Players []string `bson:"players" json:"players"`
+}
+
+type EventResult struct {
+	Entrant  string   `bson:"entrant" json:"entrant"`
+	Players  []string `bson:"players" json:"players"`
+	Gross    int      `bson:"gross" json:"gross"`
+	Handicap float64  `bson:"handicap" json:"handicap"`
+	Net      float64  `bson:"net" json:"net"`
+	Place    int      `bson:"place" json:"place"`
+	Points   float64  `bson:"points" json:"points"`
+	Payout   float64  `bson:"payout" json:"payout"`
+}
+
+type Standing struct {
+	PlayerName string   `json:"playerName"`
+	Events     int      `json:"events"`
+	Wins       int      `json:"wins"`
+	Points     float64  `json:"points"`
+	Payouts    float64  `json:"payouts"`
+	AverageNet *float64 `json:"averageNet,omitempty"`
+	Rank       int      `json:"rank"`
+}
+
+type CreateLeagueInput struct {
+	Name            string
+	PointsTable     []float64
+	PayoutTable     []float64
+	HandicapRounds  int
+	HandicapPercent float64
+}
+
+type UpdateLeagueInput struct {
+	Name            *string
+	PointsTable     []float64
+	PayoutTable     []float64
+	HandicapRounds  *int
+	HandicapPercent *float64
+}
+
+type CreateSeasonInput struct {
+	Name      string
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+type CreateEventInput struct {
+	Name     string
+	Date     time.Time
+	Format   string
+	EntryFee float64
+	Teams    []Team
+}
@@ -0,0 +1,165 @@
+package league
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// One card on an event round: a single player, or a doubles team
+type Entrant struct {
+	Name     string
+	Players  []string
+	Gross    int
+	Handicap float64
+}
+
+// Handicap from a player's prior rounds, given as strokes over par per hole,
+// most recent first. Scaled to the event's hole count.
+func Handicap(l *League, priorPerHole []float64, holes int) float64 {
+	n := l.HandicapRounds
+	if n <= 0 || n > len(priorPerHole) {
+		n = len(priorPerHole)
+	}
+	if n == 0 {
+		return 0
+	}
+
+	sum := 0.0
+	for _, v := range priorPerHole[:n] {
+		sum += v
+	}
+
+	return round1(sum / float64(n) * float64(holes) * l.HandicapPercent)
+}
+
+// Team handicap is the average of its members'
+func TeamHandicap(handicaps []float64) float64 {
+	if len(handicaps) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, h := range handicaps {
+		sum += h
+	}
+	return round1(sum / float64(len(handicaps)))
+}
+
+// Places entrants by net score. Tied entrants share a place and split the
+// points and payouts of the positions they cover.
+func ScoreEvent(l *League, entrants []Entrant, entryFee float64) []EventResult {
+	results := make([]EventResult, len(entrants))
+	paying := 0
+	for i, e := range entrants {
+		results[i] = EventResult{
+			Entrant:  e.Name,
+			Players:  e.Players,
+			Gross:    e.Gross,
+			Handicap: e.Handicap,
+			Net:      round1(float64(e.Gross) - e.Handicap),
+		}
+		paying += len(e.Players)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Net != results[j].Net {
+			return results[i].Net < results[j].Net
+		}
+		return results[i].Gross < results[j].Gross
+	})
+
+	pot := entryFee * float64(paying)
+
+	for start := 0; start < len(results); {
+		end := start + 1
+		for end < len(results) && results[end].Net == results[start].Net {
+			end++
+		}
+
+		points, share := 0.0, 0.0
+		for pos := start; pos < end; pos++ {
+			if pos < len(l.PointsTable) {
+				points += l.PointsTable[pos]
+			}
+			if pos < len(l.PayoutTable) {
+				share += l.PayoutTable[pos]
+			}
+		}
+		tied := float64(end - start)
+
+		for i := start; i < end; i++ {
+			results[i].Place = start + 1
+			results[i].Points = round2(points / tied)
+			results[i].Payout = round2(share / tied * pot)
+		}
+
+		start = end
+	}
+
+	return results
+}
+
+// Totals scored events per player. Team members each get the team's points,
+// and split its payout.
+func BuildStandings(events []Event) []Standing {
+	byPlayer := make(map[string]*Standing)
+	netSums := make(map[string]float64)
+
+	for _, e := range events {
+		for _, r := range e.Results {
+			for _, name := range r.Players {
+				key := strings.ToLower(strings.TrimSpace(name))
+				st, ok := byPlayer[key]
+				if !ok {
+					st = &Standing{PlayerName: strings.TrimSpace(name)}
+					byPlayer[key] = st
+				}
+
+				st.Events++
+				st.Points += r.Points
+				st.Payouts += r.Payout / float64(len(r.Players))
+				if r.Place == 1 {
+					st.Wins++
+				}
+				netSums[key] += r.Net
+			}
+		}
+	}
+
+	standings := make([]Standing, 0, len(byPlayer))
+	for key, st := range byPlayer {
+		avg := round1(netSums[key] / float64(st.Events))
+		st.AverageNet = &avg
+		st.Points = round2(st.Points)
+		st.Payouts = round2(st.Payouts)
+		standings = append(standings, *st)
+	}
+
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].Points != standings[j].Points {
+			return standings[i].Points > standings[j].Points
+		}
+		if standings[i].Wins != standings[j].Wins {
+			return standings[i].Wins > standings[j].Wins
+		}
+		return standings[i].PlayerName < standings[j].PlayerName
+	})
+
+	for i := range standings {
+		if i > 0 && standings[i].Points == standings[i-1].Points {
+			standings[i].Rank = standings[i-1].Rank
+		} else {
+			standings[i].Rank = i + 1
+		}
+	}
+
+	return standings
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10)/10 + 0
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100)/100 + 0
+}
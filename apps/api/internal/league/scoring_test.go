@@ -0,0 +1,130 @@
+package league
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHandicap(t *testing.T) {
+	tests := []struct {
+		name    string
+		rounds  int
+		percent float64
+		prior   []float64
+		holes   int
+		want    float64
+	}{
+		{"last rounds only", 3, 0.8, []float64{0.5, 0.5, 0.5, 2}, 18, 7.2},
+		{"no limit uses every round", 0, 1, []float64{1, 0}, 9, 4.5},
+		{"fewer rounds than the limit", 5, 1, []float64{0.2}, 18, 3.6},
+		{"under par gives strokes back", 3, 1, []float64{-0.25}, 18, -4.5},
+		{"no prior rounds", 3, 1, nil, 18, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &League{HandicapRounds: tt.rounds, HandicapPercent: tt.percent}
+			if got := Handicap(l, tt.prior, tt.holes); got != tt.want {
+				t.Errorf("Handicap = %g, want %g", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTeamHandicap(t *testing.T) {
+	tests := []struct {
+		handicaps []float64
+		want      float64
+	}{
+		{nil, 0},
+		{[]float64{4, 5}, 4.5},
+		{[]float64{1, 2, 2}, 1.7},
+	}
+
+	for _, tt := range tests {
+		if got := TeamHandicap(tt.handicaps); got != tt.want {
+			t.Errorf("TeamHandicap(%v) = %g, want %g", tt.handicaps, got, tt.want)
+		}
+	}
+}
+
+func TestScoreEvent(t *testing.T) {
+	entrants := []Entrant{
+		{Name: "Ann", Players: []string{"Ann"}, Gross: 60, Handicap: 4},
+		{Name: "Ben", Players: []string{"Ben"}, Gross: 58, Handicap: 2},
+		{Name: "Cal", Players: []string{"Cal"}, Gross: 59},
+		{Name: "Dee + Eve", Players: []string{"Dee", "Eve"}, Gross: 62, Handicap: 1.5},
+	}
+
+	tests := []struct {
+		name     string
+		league   *League
+		entryFee float64
+		want     []EventResult
+	}{
+		{
+			// Ann and Ben tie on net 56: they share first and split the
+			// points and payouts for first and second. Ben's lower gross
+			// lists him first. Five paying players make a pot of 25.
+			name:     "ties split points and payouts",
+			league:   &League{PointsTable: []float64{10, 8, 6}, PayoutTable: []float64{0.5, 0.3, 0.2}},
+			entryFee: 5,
+			want: []EventResult{
+				{Entrant: "Ben", Players: []string{"Ben"}, Gross: 58, Handicap: 2, Net: 56, Place: 1, Points: 9, Payout: 10},
+				{Entrant: "Ann", Players: []string{"Ann"}, Gross: 60, Handicap: 4, Net: 56, Place: 1, Points: 9, Payout: 10},
+				{Entrant: "Cal", Players: []string{"Cal"}, Gross: 59, Net: 59, Place: 3, Points: 6, Payout: 5},
+				{Entrant: "Dee + Eve", Players: []string{"Dee", "Eve"}, Gross: 62, Handicap: 1.5, Net: 60.5, Place: 4},
+			},
+		},
+		{
+			name:   "no tables and no fee",
+			league: &League{},
+			want: []EventResult{
+				{Entrant: "Ben", Players: []string{"Ben"}, Gross: 58, Handicap: 2, Net: 56, Place: 1},
+				{Entrant: "Ann", Players: []string{"Ann"}, Gross: 60, Handicap: 4, Net: 56, Place: 1},
+				{Entrant: "Cal", Players: []string{"Cal"}, Gross: 59, Net: 59, Place: 3},
+				{Entrant: "Dee + Eve", Players: []string{"Dee", "Eve"}, Gross: 62, Handicap: 1.5, Net: 60.5, Place: 4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScoreEvent(tt.league, entrants, tt.entryFee)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ScoreEvent =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildStandings(t *testing.T) {
+	events := []Event{
+		{Results: []EventResult{
+			{Players: []string{"Ben"}, Net: 56, Place: 1, Points: 9, Payout: 10},
+			{Players: []string{"Ann"}, Net: 56, Place: 1, Points: 9, Payout: 10},
+			{Players: []string{"Cal"}, Net: 59, Place: 3, Points: 6, Payout: 5},
+			{Players: []string{"Dee", "Eve"}, Net: 60.5, Place: 4},
+		}},
+		{Results: []EventResult{
+			// The same player however the card spells them.
+			{Players: []string{" ann "}, Net: 55, Place: 1, Points: 10, Payout: 12},
+			{Players: []string{"Dee", "Eve"}, Net: 57, Place: 2, Points: 8, Payout: 6},
+		}},
+	}
+
+	avg := func(v float64) *float64 { return &v }
+	want := []Standing{
+		{PlayerName: "Ann", Events: 2, Wins: 2, Points: 19, Payouts: 22, AverageNet: avg(55.5), Rank: 1},
+		{PlayerName: "Ben", Events: 1, Wins: 1, Points: 9, Payouts: 10, AverageNet: avg(56), Rank: 2},
+		// Team members each get the team's points and half its payout, and
+		// share a rank on equal points.
+		{PlayerName: "Dee", Events: 2, Points: 8, Payouts: 3, AverageNet: avg(58.8), Rank: 3},
+		{PlayerName: "Eve", Events: 2, Points: 8, Payouts: 3, AverageNet: avg(58.8), Rank: 3},
+		{PlayerName: "Cal", Events: 1, Points: 6, Payouts: 5, AverageNet: avg(59), Rank: 5},
+	}
+
+	if got := BuildStandings(events); !reflect.DeepEqual(got, want) {
+		t.Errorf("BuildStandings =\n%+v\nwant\n%+v", got, want)
+	}
+}
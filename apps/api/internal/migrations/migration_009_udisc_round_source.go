@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Rounds can now be entered manually, so the import key is scoped to imported
+// rounds only. The old unique index also keyed on "name", which rounds don't have.
+func migration009UDiscRoundSource(ctx context.Context, db *mongo.Database) error {
+	collection := db.Collection("udisc_rounds")
+
+	if _, err := collection.UpdateMany(ctx,
+		bson.M{"source": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"source": "import"}},
+	); err != nil {
+		return err
+	}
+
+	if err := collection.Indexes().DropOne(ctx, "ux_udisc_rounds_user_course_layout_time"); err != nil {
+		// 27 = IndexNotFound, fine on fresh databases
+		var se mongo.ServerError
+		if !errors.As(err, &se) || !se.HasErrorCode(27) {
+			return err
+		}
+	}
+
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "userId", Value: 1},
+				{Key: "courseName", Value: 1},
+				{Key: "layoutName", Value: 1},
+				{Key: "startTime", Value: 1},
+			},
+			Options: options.Index().
+				SetName("ux_udisc_rounds_import_user_course_layout_time").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"source": "import"}),
+		},
+		{
+			Keys: bson.D{
+				{Key: "userId", Value: 1},
+				{Key: "startTime", Value: -1},
+			},
+			Options: options.Index().
+				SetName("idx_udisc_rounds_user_start"),
+		},
+	})
+
+	return err
+}
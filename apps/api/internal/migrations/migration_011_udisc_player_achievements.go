@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+func migration011UDiscPlayerAchievementsCollection(ctx context.Context, db *mongo.Database) error {
+	collection := db.Collection("udisc_player_achievements")
+
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "userId", Value: 1},
+			{Key: "playerName", Value: 1},
+		},
+		Options: options.Index().
+			SetName("ux_udisc_player_achievements_user_player").
+			SetUnique(true),
+	})
+
+	return err
+}
@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+func migration012CreateLeagueCollections(ctx context.Context, db *mongo.Database) error {
+	if _, err := db.Collection("leagues").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "userId", Value: 1},
+			{Key: "name", Value: 1},
+		},
+		Options: options.Index().
+			SetName("ux_leagues_user_name").
+			SetUnique(true),
+	}); err != nil {
+		return err
+	}
+
+	if _, err := db.Collection("league_seasons").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "userId", Value: 1},
+			{Key: "leagueId", Value: 1},
+			{Key: "startDate", Value: -1},
+		},
+		Options: options.Index().
+			SetName("idx_league_seasons_user_league_start"),
+	}); err != nil {
+		return err
+	}
+
+	_, err := db.Collection("league_events").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "userId", Value: 1},
+				{Key: "seasonId", Value: 1},
+				{Key: "date", Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_league_events_user_season_date"),
+		},
+		{
+			Keys: bson.D{{Key: "roundId", Value: 1}},
+			Options: options.Index().
+				SetName("idx_league_events_round"),
+		},
+	})
+
+	return err
+}
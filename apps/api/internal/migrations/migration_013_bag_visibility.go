@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Bags were readable by anyone before visibility existed, so they stay public.
+func migration013BagVisibility(ctx context.Context, db *mongo.Database) error {
+	col := db.Collection(bagsCollection)
+
+	_, err := col.UpdateMany(ctx,
+		bson.M{"visibility": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"visibility": "public"}},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "viewerIds", Value: 1}},
+		Options: options.Index().
+			SetName("idx_user_bags_viewers"),
+	})
+
+	return err
+}
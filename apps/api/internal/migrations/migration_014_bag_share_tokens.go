@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+func migration014BagShareTokens(ctx context.Context, db *mongo.Database) error {
+	col := db.Collection(bagsCollection)
+
+	_, err := col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "shareTokens.token", Value: 1}},
+		Options: options.Index().
+			SetName("ux_user_bags_share_token").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"shareTokens.token": bson.M{"$exists": true}}),
+	})
+
+	return err
+}
@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Every disc saved before statuses existed was in a bag. Its history starts
+// when it was added.
+func migration015DiscStatus(ctx context.Context, db *mongo.Database) error {
+	col := db.Collection(discsCollection)
+
+	_, err := col.UpdateMany(ctx,
+		bson.M{"status": bson.M{"$exists": false}},
+		mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{
+				"status": "in_bag",
+				"statusHistory": bson.A{
+					bson.M{"status": "in_bag", "changedAt": "$createdAt"},
+				},
+			}}},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "bagId", Value: 1},
+				{Key: "status", Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_user_discs_bag_status"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "userId", Value: 1},
+				{Key: "status", Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_user_discs_user_status"),
+		},
+	})
+
+	return err
+}
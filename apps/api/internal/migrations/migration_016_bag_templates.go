@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const bagTemplatesCollection = "bag_templates"
+
+func migration016BagTemplatesCollection(ctx context.Context, db *mongo.Database) error {
+	col := db.Collection(bagTemplatesCollection)
+
+	_, err := col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "userId", Value: 1},
+			{Key: "name", Value: 1},
+		},
+		Options: options.Index().
+			SetName("ux_bag_templates_user_name").
+			SetUnique(true),
+	})
+
+	return err
+}
@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const customCatalogDiscsCollection = "custom_catalog_discs"
+
+func migration017CustomCatalogDiscsCollection(ctx context.Context, db *mongo.Database) error {
+	col := db.Collection(customCatalogDiscsCollection)
+
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "owner_user_id", Value: 1},
+				{Key: "brand_slug", Value: 1},
+				{Key: "name_slug", Value: 1},
+			},
+			Options: options.Index().
+				SetName("ux_custom_discs_owner_brand_name_slug").
+				SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "owner_user_id", Value: 1},
+				{Key: "name", Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_custom_discs_owner_name"),
+		},
+	})
+
+	return err
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Faceted search ranks by text score. Seeding creates the same index, but
+// catalogs seeded before it did would have no text index at all. The key
+// spec and default name must match seeding's or Mongo rejects the second
+// text index.
+func migration018DiscCatalogTextIndex(ctx context.Context, db *mongo.Database) error {
+	col := db.Collection(discCatalogCollection)
+
+	_, err := col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "name", Value: "text"},
+			{Key: "brand", Value: "text"},
+		},
+	})
+
+	return err
+}
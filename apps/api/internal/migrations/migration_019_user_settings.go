@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const userSettingsCollection = "user_settings"
+
+func migration019UserSettingsCollection(ctx context.Context, db *mongo.Database) error {
+	col := db.Collection(userSettingsCollection)
+
+	_, err := col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "userId", Value: 1}},
+		Options: options.Index().
+			SetName("ux_user_settings_user").
+			SetUnique(true),
+	})
+
+	return err
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const catalogOverridesCollection = "catalog_overrides"
+
+func migration020CatalogOverridesCollection(ctx context.Context, db *mongo.Database) error {
+	col := db.Collection(catalogOverridesCollection)
+
+	_, err := col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "catalog_id", Value: 1}},
+		Options: options.Index().
+			SetName("ux_catalog_overrides_catalog").
+			SetUnique(true),
+	})
+
+	return err
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const plasticsCollection = "plastics"
+
+func migration021PlasticsCollection(ctx context.Context, db *mongo.Database) error {
+	col := db.Collection(plasticsCollection)
+
+	_, err := col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "brand_slug", Value: 1},
+			{Key: "slug", Value: 1},
+		},
+		Options: options.Index().
+			SetName("ux_plastics_brand_slug").
+			SetUnique(true),
+	})
+
+	return err
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const catalogChangesCollection = "catalog_changes"
+
+func migration022CatalogChangesCollection(ctx context.Context, db *mongo.Database) error {
+	col := db.Collection(catalogChangesCollection)
+
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "at", Value: -1}},
+			Options: options.Index().
+				SetName("idx_catalog_changes_at"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "kind", Value: 1},
+				{Key: "brand_slug", Value: 1},
+				{Key: "at", Value: -1},
+			},
+			Options: options.Index().
+				SetName("idx_catalog_changes_kind_brand_at"),
+		},
+	})
+
+	return err
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Custom discs are searched next to the catalog, so they need the same text
+// index: with the same keys and weights their text scores rank on the same
+// scale as catalog discs.
+func migration023CustomCatalogDiscsTextIndex(ctx context.Context, db *mongo.Database) error {
+	col := db.Collection(customCatalogDiscsCollection)
+
+	_, err := col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "name", Value: "text"},
+			{Key: "brand", Value: "text"},
+		},
+	})
+
+	return err
+}
@@ -48,6 +48,66 @@ var migrations = []Migration{
 		Name: "008_create_udisc_rounds_collection",
 		Up:   migration008UDiscRoundsCollection,
 	},
+	{
+		Name: "009_udisc_round_source",
+		Up:   migration009UDiscRoundSource,
+	},
+	{
+		Name: "010_create_udisc_player_aliases_collection",
+		Up:   migration010UDiscPlayerAliasesCollection,
+	},
+	{
+		Name: "011_create_udisc_player_achievements_collection",
+		Up:   migration011UDiscPlayerAchievementsCollection,
+	},
+	{
+		Name: "012_create_league_collections",
+		Up:   migration012CreateLeagueCollections,
+	},
+	{
+		Name: "013_bag_visibility",
+		Up:   migration013BagVisibility,
+	},
+	{
+		Name: "014_bag_share_tokens",
+		Up:   migration014BagShareTokens,
+	},
+	{
+		Name: "015_disc_status",
+		Up:   migration015DiscStatus,
+	},
+	{
+		Name: "016_create_bag_templates_collection",
+		Up:   migration016BagTemplatesCollection,
+	},
+	{
+		Name: "017_create_custom_catalog_discs_collection",
+		Up:   migration017CustomCatalogDiscsCollection,
+	},
+	{
+		Name: "018_disc_catalog_text_index",
+		Up:   migration018DiscCatalogTextIndex,
+	},
+	{
+		Name: "019_create_user_settings_collection",
+		Up:   migration019UserSettingsCollection,
+	},
+	{
+		Name: "020_create_catalog_overrides_collection",
+		Up:   migration020CatalogOverridesCollection,
+	},
+	{
+		Name: "021_create_plastics_collection",
+		Up:   migration021PlasticsCollection,
+	},
+	{
+		Name: "022_create_catalog_changes_collection",
+		Up:   migration022CatalogChangesCollection,
+	},
+	{
+		Name: "023_custom_catalog_discs_text_index",
+		Up:   migration023CustomCatalogDiscsTextIndex,
+	},
 }
 
 func Run(ctx context.Context, db *mongo.Database) error {
@@ -12,7 +12,9 @@ type BagRepository interface {
 	Create(ctx context.Context, bag *bag.Bag) error
 	FindByID(ctx context.Context, _id bson.ObjectID) (*bag.Bag, error)
 	FindByUserID(ctx context.Context, userID string) ([]*bag.Bag, error)
+	FindByShareToken(ctx context.Context, token string) (*bag.Bag, error)
 	Update(ctx context.Context, bag *bag.Bag) error
+	SetShareTokens(ctx context.Context, _id bson.ObjectID, tokens []bag.ShareToken) error
 	Delete(ctx context.Context, _id bson.ObjectID) error
 }
 
@@ -74,12 +76,26 @@ func (r *MongoBagRepository) FindByUserID(ctx context.Context, userID string) ([
 	return bags, nil
 }
 
+func (r *MongoBagRepository) FindByShareToken(ctx context.Context, token string) (*bag.Bag, error) {
+	var result bag.Bag
+	err := r.collection.FindOne(ctx, bson.M{"shareTokens.token": token}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
 func (r *MongoBagRepository) Update(ctx context.Context, bag *bag.Bag) error {
 	filter := bson.M{"_id": bag.ID}
 	update := bson.M{
 		"$set": bson.M{
-			"name":      bag.Name,
-			"updatedAt": bag.UpdatedAt,
+			"name":       bag.Name,
+			"visibility": bag.Visibility,
+			"viewerIds":  bag.ViewerIDs,
+			"updatedAt":  bag.UpdatedAt,
 		},
 	}
 
@@ -87,6 +103,14 @@ func (r *MongoBagRepository) Update(ctx context.Context, bag *bag.Bag) error {
 	return err
 }
 
+func (r *MongoBagRepository) SetShareTokens(ctx context.Context, _id bson.ObjectID, tokens []bag.ShareToken) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": _id},
+		bson.M{"$set": bson.M{"shareTokens": tokens}},
+	)
+	return err
+}
+
 func (r *MongoBagRepository) Delete(ctx context.Context, _id bson.ObjectID) error {
 	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": _id})
 	return err
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Tidwell32/zack/apps/api/internal/bag"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type BagTemplateRepository interface {
+	Save(ctx context.Context, template *bag.Template) error
+	FindByID(ctx context.Context, _id bson.ObjectID, userID string) (*bag.Template, error)
+	FindByName(ctx context.Context, userID, name string) (*bag.Template, error)
+	FindByUserID(ctx context.Context, userID string) ([]*bag.Template, error)
+	Delete(ctx context.Context, _id bson.ObjectID, userID string) (bool, error)
+}
+
+type MongoBagTemplateRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoBagTemplateRepository(collection *mongo.Collection) BagTemplateRepository {
+	return &MongoBagTemplateRepository{
+		collection: collection,
+	}
+}
+
+func (r *MongoBagTemplateRepository) Save(ctx context.Context, template *bag.Template) error {
+	_, err := r.collection.ReplaceOne(ctx,
+		bson.M{"_id": template.ID, "userId": template.UserID},
+		template,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *MongoBagTemplateRepository) FindByID(ctx context.Context, _id bson.ObjectID, userID string) (*bag.Template, error) {
+	var result bag.Template
+	err := r.collection.FindOne(ctx, bson.M{"_id": _id, "userId": userID}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *MongoBagTemplateRepository) FindByName(ctx context.Context, userID, name string) (*bag.Template, error) {
+	var result bag.Template
+	err := r.collection.FindOne(ctx, bson.M{"userId": userID, "name": name}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *MongoBagTemplateRepository) FindByUserID(ctx context.Context, userID string) ([]*bag.Template, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cur, err := r.collection.Find(ctx, bson.M{"userId": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	templates := []*bag.Template{}
+	for cur.Next(ctx) {
+		var t bag.Template
+		if err := cur.Decode(&t); err != nil {
+			return nil, err
+		}
+		templates = append(templates, &t)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (r *MongoBagTemplateRepository) Delete(ctx context.Context, _id bson.ObjectID, userID string) (bool, error) {
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": _id, "userId": userID})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
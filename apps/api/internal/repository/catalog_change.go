@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type CatalogChangeQuery struct {
+	Since      time.Time
+	Kinds      []string
+	BrandSlugs []string
+	Limit      int
+}
+
+type CatalogChangeRepository interface {
+	Find(ctx context.Context, query CatalogChangeQuery) ([]catalog.Change, error)
+}
+
+type MongoCatalogChangeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoCatalogChangeRepository(collection *mongo.Collection) CatalogChangeRepository {
+	return &MongoCatalogChangeRepository{
+		collection: collection,
+	}
+}
+
+// Find returns changes at or after query.Since, newest first.
+func (r *MongoCatalogChangeRepository) Find(ctx context.Context, query CatalogChangeQuery) ([]catalog.Change, error) {
+	filter := bson.M{"at": bson.M{"$gte": query.Since}}
+	if len(query.Kinds) > 0 {
+		filter["kind"] = bson.M{"$in": query.Kinds}
+	}
+	if len(query.BrandSlugs) > 0 {
+		filter["brand_slug"] = bson.M{"$in": query.BrandSlugs}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "at", Value: -1}, {Key: "brand_slug", Value: 1}, {Key: "name", Value: 1}})
+	if query.Limit > 0 {
+		opts.SetLimit(int64(query.Limit))
+	}
+
+	cur, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	changes := []catalog.Change{}
+	if err := cur.All(ctx, &changes); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type CatalogOverrideRepository interface {
+	FindByCatalogID(ctx context.Context, catalogID bson.ObjectID) (*catalog.Override, error)
+	Save(ctx context.Context, override *catalog.Override) error
+}
+
+type MongoCatalogOverrideRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoCatalogOverrideRepository(collection *mongo.Collection) CatalogOverrideRepository {
+	return &MongoCatalogOverrideRepository{
+		collection: collection,
+	}
+}
+
+func (r *MongoCatalogOverrideRepository) FindByCatalogID(ctx context.Context, catalogID bson.ObjectID) (*catalog.Override, error) {
+	var result catalog.Override
+	err := r.collection.FindOne(ctx, bson.M{"catalog_id": catalogID}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *MongoCatalogOverrideRepository) Save(ctx context.Context, override *catalog.Override) error {
+	_, err := r.collection.ReplaceOne(ctx,
+		bson.M{"_id": override.ID},
+		override,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// CustomDiscRepository stores user-created catalog entries. Every lookup is
+// scoped to the owning user.
+type CustomDiscRepository interface {
+	Create(ctx context.Context, d *catalog.CustomDisc) error
+	FindByID(ctx context.Context, _id bson.ObjectID, userID string) (*catalog.CustomDisc, error)
+	FindBySlug(ctx context.Context, userID, brandSlug, nameSlug string) (*catalog.CustomDisc, error)
+	FindByUserID(ctx context.Context, userID string) ([]*catalog.CustomDisc, error)
+	Search(ctx context.Context, userID, query string, limit int) ([]*catalog.CustomDisc, error)
+	Update(ctx context.Context, d *catalog.CustomDisc) error
+	Delete(ctx context.Context, _id bson.ObjectID, userID string) (bool, error)
+}
+
+type MongoCustomDiscRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoCustomDiscRepository(collection *mongo.Collection) CustomDiscRepository {
+	return &MongoCustomDiscRepository{
+		collection: collection,
+	}
+}
+
+func (r *MongoCustomDiscRepository) Create(ctx context.Context, d *catalog.CustomDisc) error {
+	_, err := r.collection.InsertOne(ctx, d)
+	return err
+}
+
+func (r *MongoCustomDiscRepository) FindByID(ctx context.Context, _id bson.ObjectID, userID string) (*catalog.CustomDisc, error) {
+	return r.findOne(ctx, bson.M{"_id": _id, "owner_user_id": userID})
+}
+
+func (r *MongoCustomDiscRepository) FindBySlug(ctx context.Context, userID, brandSlug, nameSlug string) (*catalog.CustomDisc, error) {
+	return r.findOne(ctx, bson.M{
+		"owner_user_id": userID,
+		"brand_slug":    brandSlug,
+		"name_slug":     nameSlug,
+	})
+}
+
+func (r *MongoCustomDiscRepository) FindByUserID(ctx context.Context, userID string) ([]*catalog.CustomDisc, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "brand", Value: 1}, {Key: "name", Value: 1}})
+	return r.find(ctx, bson.M{"owner_user_id": userID}, opts)
+}
+
+func (r *MongoCustomDiscRepository) Search(ctx context.Context, userID, query string, limit int) ([]*catalog.CustomDisc, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	filter := bson.M{
+		"owner_user_id": userID,
+		"name": bson.M{
+			"$regex":   regexp.QuoteMeta(query),
+			"$options": "i",
+		},
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}}).SetLimit(int64(limit))
+	return r.find(ctx, filter, opts)
+}
+
+func (r *MongoCustomDiscRepository) Update(ctx context.Context, d *catalog.CustomDisc) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": d.ID, "owner_user_id": d.OwnerUserID}, d)
+	return err
+}
+
+func (r *MongoCustomDiscRepository) Delete(ctx context.Context, _id bson.ObjectID, userID string) (bool, error) {
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": _id, "owner_user_id": userID})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+func (r *MongoCustomDiscRepository) findOne(ctx context.Context, filter bson.M) (*catalog.CustomDisc, error) {
+	var d catalog.CustomDisc
+	err := r.collection.FindOne(ctx, filter).Decode(&d)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *MongoCustomDiscRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptionsBuilder) ([]*catalog.CustomDisc, error) {
+	cur, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	discs := []*catalog.CustomDisc{}
+	for cur.Next(ctx) {
+		var d catalog.CustomDisc
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		discs = append(discs, &d)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return discs, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/Tidwell32/zack/apps/api/internal/disc"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -10,11 +11,25 @@ import (
 
 type DiscRepository interface {
 	Create(ctx context.Context, disc *disc.Disc) error
+	CreateMany(ctx context.Context, discs []*disc.Disc) error
 	FindByID(ctx context.Context, _id bson.ObjectID) (*disc.Disc, error)
 	FindByBagID(ctx context.Context, bagID bson.ObjectID) ([]*disc.Disc, error)
 	FindByUserID(ctx context.Context, userID string) ([]*disc.Disc, error)
+	FindAll(ctx context.Context) ([]*disc.Disc, error)
 	Update(ctx context.Context, disc *disc.Disc) error
 	Delete(ctx context.Context, _id bson.ObjectID) error
+	AddPhoto(ctx context.Context, discID bson.ObjectID, photo disc.Photo) error
+	RemovePhoto(ctx context.Context, discID, photoID bson.ObjectID) error
+	SetCatalogLink(ctx context.Context, _id bson.ObjectID, catalogDiscID string, stock disc.FlightNumbers) error
+
+	// Bag cascade helpers. Discs in the bag have status in_bag; the rest
+	// (lost, retired, ...) are only linked to it.
+	CountByBagID(ctx context.Context, bagID bson.ObjectID) (inBag, linked int64, err error)
+	ReassignBag(ctx context.Context, fromBagID, toBagID bson.ObjectID) (int64, error)
+	ShelveBag(ctx context.Context, bagID bson.ObjectID, change disc.StatusChange) (int64, error)
+	DeleteInBag(ctx context.Context, bagID bson.ObjectID) (int64, error)
+	DetachBag(ctx context.Context, bagID bson.ObjectID) (int64, error)
+	DistinctBagIDs(ctx context.Context) ([]bson.ObjectID, error)
 }
 
 type MongoDiscRepository struct {
@@ -40,6 +55,20 @@ func (r *MongoDiscRepository) Create(ctx context.Context, d *disc.Disc) error {
 	return nil
 }
 
+func (r *MongoDiscRepository) CreateMany(ctx context.Context, discs []*disc.Disc) error {
+	if len(discs) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(discs))
+	for i, d := range discs {
+		docs[i] = d
+	}
+
+	_, err := r.collection.InsertMany(ctx, docs)
+	return err
+}
+
 func (r *MongoDiscRepository) FindByID(ctx context.Context, _id bson.ObjectID) (*disc.Disc, error) {
 	var result disc.Disc
 	err := r.collection.FindOne(ctx, bson.M{"_id": _id}).Decode(&result)
@@ -53,7 +82,9 @@ func (r *MongoDiscRepository) FindByID(ctx context.Context, _id bson.ObjectID) (
 }
 
 func (r *MongoDiscRepository) FindByBagID(ctx context.Context, bagID bson.ObjectID) ([]*disc.Disc, error) {
-	cur, err := r.collection.Find(ctx, bson.M{"bagId": bagID})
+	// Discs keep their bagId while on the shelf so they can go back to it;
+	// only the ones currently in the bag belong to its contents.
+	cur, err := r.collection.Find(ctx, bson.M{"bagId": bagID, "status": disc.StatusInBag})
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +107,15 @@ func (r *MongoDiscRepository) FindByBagID(ctx context.Context, bagID bson.Object
 }
 
 func (r *MongoDiscRepository) FindByUserID(ctx context.Context, userID string) ([]*disc.Disc, error) {
-	cur, err := r.collection.Find(ctx, bson.M{"userId": userID})
+	return r.find(ctx, bson.M{"userId": userID})
+}
+
+func (r *MongoDiscRepository) FindAll(ctx context.Context) ([]*disc.Disc, error) {
+	return r.find(ctx, bson.M{})
+}
+
+func (r *MongoDiscRepository) find(ctx context.Context, filter bson.M) ([]*disc.Disc, error) {
+	cur, err := r.collection.Find(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -100,16 +139,27 @@ func (r *MongoDiscRepository) FindByUserID(ctx context.Context, userID string) (
 
 func (r *MongoDiscRepository) Update(ctx context.Context, d *disc.Disc) error {
 	filter := bson.M{"_id": d.ID}
-	update := bson.M{
-		"$set": bson.M{
-			"bagId":          d.BagID,
-			"plastic":        d.Plastic,
-			"weight":         d.Weight,
-			"colorHex":       d.ColorHex,
-			"notes":          d.Notes,
-			"adjustedFlight": d.AdjustedFlight,
-			"updatedAt":      d.UpdatedAt,
-		},
+	set := bson.M{
+		"plastic":        d.Plastic,
+		"weight":         d.Weight,
+		"colorHex":       d.ColorHex,
+		"notes":          d.Notes,
+		"adjustedFlight": d.AdjustedFlight,
+		"flightHistory":  d.FlightHistory,
+		"status":         d.Status,
+		"statusHistory":  d.StatusHistory,
+		"lostCourse":     d.LostCourse,
+		"lostAt":         d.LostAt,
+		"recoveredAt":    d.RecoveredAt,
+		"updatedAt":      d.UpdatedAt,
+	}
+	update := bson.M{"$set": set}
+
+	// Discs shelved by a bag deletion have no bag to point at.
+	if d.BagID.IsZero() {
+		update["$unset"] = bson.M{"bagId": ""}
+	} else {
+		set["bagId"] = d.BagID
 	}
 
 	_, err := r.collection.UpdateOne(ctx, filter, update)
@@ -120,3 +170,114 @@ func (r *MongoDiscRepository) Delete(ctx context.Context, _id bson.ObjectID) err
 	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": _id})
 	return err
 }
+
+// SetCatalogLink points the disc at a catalog entry and sets its stock
+// flight numbers, leaving the user's adjusted numbers alone.
+func (r *MongoDiscRepository) SetCatalogLink(ctx context.Context, _id bson.ObjectID, catalogDiscID string, stock disc.FlightNumbers) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": _id},
+		bson.M{"$set": bson.M{
+			"catalogDiscId": catalogDiscID,
+			"stockFlight":   stock,
+			"updatedAt":     time.Now().UTC(),
+		}},
+	)
+	return err
+}
+
+func (r *MongoDiscRepository) AddPhoto(ctx context.Context, discID bson.ObjectID, photo disc.Photo) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": discID},
+		bson.M{
+			"$push": bson.M{"photos": photo},
+			"$set":  bson.M{"updatedAt": time.Now().UTC()},
+		},
+	)
+	return err
+}
+
+func (r *MongoDiscRepository) RemovePhoto(ctx context.Context, discID, photoID bson.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": discID},
+		bson.M{
+			"$pull": bson.M{"photos": bson.M{"_id": photoID}},
+			"$set":  bson.M{"updatedAt": time.Now().UTC()},
+		},
+	)
+	return err
+}
+
+func (r *MongoDiscRepository) CountByBagID(ctx context.Context, bagID bson.ObjectID) (int64, int64, error) {
+	inBag, err := r.collection.CountDocuments(ctx, bson.M{"bagId": bagID, "status": disc.StatusInBag})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	linked, err := r.collection.CountDocuments(ctx, bson.M{"bagId": bagID, "status": bson.M{"$ne": disc.StatusInBag}})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return inBag, linked, nil
+}
+
+// ReassignBag moves the discs still in fromBagID over to toBagID. Lost,
+// sold and other discs only linked to the bag stay where they are.
+func (r *MongoDiscRepository) ReassignBag(ctx context.Context, fromBagID, toBagID bson.ObjectID) (int64, error) {
+	res, err := r.collection.UpdateMany(ctx,
+		bson.M{"bagId": fromBagID, "status": disc.StatusInBag},
+		bson.M{"$set": bson.M{"bagId": toBagID, "updatedAt": time.Now().UTC()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// ShelveBag takes every disc out of the bag onto the backup shelf and
+// records the change in each disc's status history.
+func (r *MongoDiscRepository) ShelveBag(ctx context.Context, bagID bson.ObjectID, change disc.StatusChange) (int64, error) {
+	res, err := r.collection.UpdateMany(ctx,
+		bson.M{"bagId": bagID, "status": disc.StatusInBag},
+		bson.M{
+			"$set":   bson.M{"status": change.Status, "updatedAt": change.ChangedAt},
+			"$unset": bson.M{"bagId": ""},
+			"$push":  bson.M{"statusHistory": change},
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+func (r *MongoDiscRepository) DeleteInBag(ctx context.Context, bagID bson.ObjectID) (int64, error) {
+	res, err := r.collection.DeleteMany(ctx, bson.M{"bagId": bagID, "status": disc.StatusInBag})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+func (r *MongoDiscRepository) DetachBag(ctx context.Context, bagID bson.ObjectID) (int64, error) {
+	res, err := r.collection.UpdateMany(ctx,
+		bson.M{"bagId": bagID},
+		bson.M{
+			"$set":   bson.M{"updatedAt": time.Now().UTC()},
+			"$unset": bson.M{"bagId": ""},
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+func (r *MongoDiscRepository) DistinctBagIDs(ctx context.Context) ([]bson.ObjectID, error) {
+	var ids []bson.ObjectID
+	err := r.collection.Distinct(ctx, "bagId", bson.M{"bagId": bson.M{"$exists": true}}).Decode(&ids)
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
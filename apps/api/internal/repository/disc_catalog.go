@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"regexp"
 
 	"github.com/Tidwell32/zack/apps/api/internal/catalog"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -10,19 +11,38 @@ import (
 )
 
 type SuggestCriteria struct {
-	MinSpeed     float64
-	MaxSpeed     float64
-	MinStability float64 // stability = fade + turn
-	MaxStability float64
-	Limit        int
+	MinSpeed      float64
+	MaxSpeed      float64
+	MinStability  float64 // stability = fade + turn
+	MaxStability  float64
+	Glide         FloatRange
+	CategorySlugs []string
+	Limit         int
+
+	// Brand slugs: preferred brands win distance ties in list order,
+	// excluded brands are never returned.
+	PreferredBrands []string
+	ExcludedBrands  []string
+
+	// ExcludeOwned drops the user's molds entirely; otherwise
+	// OwnedMoldPenalty is added to their distance from the midpoint.
+	// The service resolves it into the catalog IDs below.
+	ExcludeOwned      bool
+	OwnedCatalogIDs   []string
+	OwnedMoldPenalty  float64
+	ExcludeCatalogIDs []string
 }
 
 type DiscCatalogRepository interface {
 	FindByID(ctx context.Context, id string) (*catalog.CatalogDisc, error)
+	FindAll(ctx context.Context) ([]*catalog.CatalogDisc, error)
+	FindByIDs(ctx context.Context, ids []bson.ObjectID) ([]*catalog.CatalogDisc, error)
+	ApplyOverride(ctx context.Context, id bson.ObjectID, fields catalog.OverrideFields) error
 	FindBySlug(ctx context.Context, brandSlug, nameSlug string) (*catalog.CatalogDisc, error)
 	Search(ctx context.Context, query string, limit int) ([]*catalog.CatalogDisc, error)
 	FindByCategory(ctx context.Context, categorySlug string) ([]*catalog.CatalogDisc, error)
 	Suggest(ctx context.Context, criteria SuggestCriteria) ([]*catalog.CatalogDisc, error)
+	FacetedSearch(ctx context.Context, query CatalogSearchQuery) (*catalog.SearchPage, error)
 }
 
 type MongoDiscCatalogRepository struct {
@@ -52,6 +72,63 @@ func (r *MongoDiscCatalogRepository) FindByID(ctx context.Context, id string) (*
 	return &disc, nil
 }
 
+func (r *MongoDiscCatalogRepository) FindAll(ctx context.Context) ([]*catalog.CatalogDisc, error) {
+	return r.find(ctx, bson.M{})
+}
+
+func (r *MongoDiscCatalogRepository) FindByIDs(ctx context.Context, ids []bson.ObjectID) ([]*catalog.CatalogDisc, error) {
+	return r.find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+}
+
+func (r *MongoDiscCatalogRepository) find(ctx context.Context, filter bson.M) ([]*catalog.CatalogDisc, error) {
+	cur, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var discs []*catalog.CatalogDisc
+	for cur.Next(ctx) {
+		var disc catalog.CatalogDisc
+		if err := cur.Decode(&disc); err != nil {
+			return nil, err
+		}
+		discs = append(discs, &disc)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return discs, nil
+}
+
+// ApplyOverride writes corrected fields onto the stored catalog disc so
+// queries filter and rank on them.
+func (r *MongoDiscCatalogRepository) ApplyOverride(ctx context.Context, id bson.ObjectID, fields catalog.OverrideFields) error {
+	var d catalog.CatalogDisc
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&d); err != nil {
+		return err
+	}
+	fields.Apply(&d)
+
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"category":       d.Category,
+			"category_slug":  d.CategorySlug,
+			"stability":      d.Stability,
+			"stability_slug": d.StabilitySlug,
+			"speed":          d.Speed,
+			"glide":          d.Glide,
+			"turn":           d.Turn,
+			"fade":           d.Fade,
+			"pic":            d.Pic,
+		}},
+	)
+	return err
+}
+
 func (r *MongoDiscCatalogRepository) FindBySlug(ctx context.Context, brandSlug, nameSlug string) (*catalog.CatalogDisc, error) {
 	var disc catalog.CatalogDisc
 	err := r.collection.FindOne(ctx, bson.M{
@@ -74,7 +151,7 @@ func (r *MongoDiscCatalogRepository) Search(ctx context.Context, query string, l
 
 	filter := bson.M{
 		"name": bson.M{
-			"$regex":   query,
+			"$regex":   regexp.QuoteMeta(query),
 			"$options": "i",
 		},
 	}
@@ -130,8 +207,27 @@ func (r *MongoDiscCatalogRepository) Suggest(ctx context.Context, criteria Sugge
 		criteria.Limit = 10
 	}
 
-	// My favorite brands :)
-	preferredBrands := []string{"mvp", "axiom discs", "mint discs", "kastaplast", "innova", "thought space athletics", "latitude 64", "discraft", "westside discs"}
+	match := bson.M{
+		"speed": bson.M{
+			"$gte": criteria.MinSpeed,
+			"$lte": criteria.MaxSpeed,
+		},
+		"stability": bson.M{
+			"$gte": criteria.MinStability,
+			"$lte": criteria.MaxStability,
+		},
+		"discontinued": bson.M{"$ne": true},
+	}
+	addRange(match, "glide", criteria.Glide)
+	if len(criteria.CategorySlugs) > 0 {
+		match["category_slug"] = bson.M{"$in": criteria.CategorySlugs}
+	}
+	if len(criteria.ExcludedBrands) > 0 {
+		match["brand_slug"] = bson.M{"$nin": criteria.ExcludedBrands}
+	}
+	if len(criteria.ExcludeCatalogIDs) > 0 {
+		match["_id"] = bson.M{"$nin": objectIDs(criteria.ExcludeCatalogIDs)}
+	}
 
 	// Some crazy aggregation that Claude wrote :)
 	pipeline := bson.A{
@@ -142,38 +238,31 @@ func (r *MongoDiscCatalogRepository) Suggest(ctx context.Context, criteria Sugge
 			},
 		},
 		// Stage 2: Match criteria
-		bson.M{
-			"$match": bson.M{
-				"speed": bson.M{
-					"$gte": criteria.MinSpeed,
-					"$lte": criteria.MaxSpeed,
-				},
-				"stability": bson.M{
-					"$gte": criteria.MinStability,
-					"$lte": criteria.MaxStability,
-				},
-			},
-		},
+		bson.M{"$match": match},
 		// Stage 3: Add distance from midpoint, brand priority, and preferred flag
 		bson.M{
 			"$addFields": bson.M{
 				"distanceFromMid": bson.M{
-					"$sqrt": bson.M{
-						"$add": bson.A{
-							bson.M{"$pow": bson.A{bson.M{"$subtract": bson.A{"$speed", (criteria.MinSpeed + criteria.MaxSpeed) / 2}}, 2}},
-							bson.M{"$pow": bson.A{bson.M{"$subtract": bson.A{"$stability", (criteria.MinStability + criteria.MaxStability) / 2}}, 2}},
+					"$add": bson.A{
+						bson.M{
+							"$sqrt": bson.M{
+								"$add": bson.A{
+									bson.M{"$pow": bson.A{bson.M{"$subtract": bson.A{"$speed", (criteria.MinSpeed + criteria.MaxSpeed) / 2}}, 2}},
+									bson.M{"$pow": bson.A{bson.M{"$subtract": bson.A{"$stability", (criteria.MinStability + criteria.MaxStability) / 2}}, 2}},
+								},
+							},
 						},
+						bson.M{"$cond": bson.A{
+							bson.M{"$in": bson.A{"$_id", objectIDs(criteria.OwnedCatalogIDs)}},
+							criteria.OwnedMoldPenalty,
+							0,
+						}},
 					},
 				},
 				"isPreferredBrand": bson.M{
-					"$in": bson.A{bson.M{"$toLower": "$brand"}, preferredBrands},
-				},
-				"brandPriority": bson.M{
-					"$switch": bson.M{
-						"branches": buildBrandPriorityBranches(preferredBrands),
-						"default":  len(preferredBrands), // Non-preferred brands get lowest priority
-					},
+					"$in": bson.A{"$brand_slug", nonNil(criteria.PreferredBrands)},
 				},
+				"brandPriority": brandPriority(criteria.PreferredBrands),
 			},
 		},
 		// Stage 4: Sort by distance (closest to middle first), then prefer preferred brands, then by brand rank
@@ -219,13 +308,46 @@ func (r *MongoDiscCatalogRepository) Suggest(ctx context.Context, criteria Sugge
 	return discs, nil
 }
 
-func buildBrandPriorityBranches(brands []string) bson.A {
+// brandPriority ranks preferred brands by list position. $switch needs at
+// least one branch, so an empty list ranks every brand the same.
+func brandPriority(brandSlugs []string) any {
+	if len(brandSlugs) == 0 {
+		return bson.M{"$literal": 0}
+	}
+	return bson.M{
+		"$switch": bson.M{
+			"branches": buildBrandPriorityBranches(brandSlugs),
+			"default":  len(brandSlugs), // Non-preferred brands get lowest priority
+		},
+	}
+}
+
+func buildBrandPriorityBranches(brandSlugs []string) bson.A {
 	branches := bson.A{}
-	for i, brand := range brands {
+	for i, slug := range brandSlugs {
 		branches = append(branches, bson.M{
-			"case": bson.M{"$eq": bson.A{bson.M{"$toLower": "$brand"}, brand}},
+			"case": bson.M{"$eq": bson.A{"$brand_slug", slug}},
 			"then": i,
 		})
 	}
 	return branches
 }
+
+// objectIDs converts hex catalog IDs, skipping any that don't parse (custom
+// discs and stale links can't match the DiscIt catalog anyway).
+func objectIDs(ids []string) bson.A {
+	oids := bson.A{}
+	for _, id := range ids {
+		if oid, err := bson.ObjectIDFromHex(id); err == nil {
+			oids = append(oids, oid)
+		}
+	}
+	return oids
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
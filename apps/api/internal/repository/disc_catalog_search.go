@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// FloatRange bounds a flight number; nil ends are open.
+type FloatRange struct {
+	Min *float64
+	Max *float64
+}
+
+type CatalogSearchQuery struct {
+	Text string
+	// UserID includes that user's custom discs in the results.
+	UserID         string
+	BrandSlugs     []string
+	CategorySlugs  []string
+	StabilitySlugs []string
+	Speed          FloatRange
+	Glide          FloatRange
+	Turn           FloatRange
+	Fade           FloatRange
+	After          *catalog.SearchCursor
+	Limit          int
+}
+
+const customCatalogDiscsCollection = "custom_catalog_discs"
+
+type searchFacetResult struct {
+	Results []struct {
+		catalog.CatalogDisc `bson:",inline"`
+		Score               float64 `bson:"score"`
+	} `bson:"results"`
+	Total []struct {
+		Count int `bson:"count"`
+	} `bson:"total"`
+	Brands     []catalog.FacetCount `bson:"brands"`
+	Categories []catalog.FacetCount `bson:"categories"`
+}
+
+// FacetedSearch filters and ranks the catalog. A text query is matched
+// with the text index first; if that finds nothing (usually a partial
+// word) it falls back to an escaped substring match on name and brand.
+func (r *MongoDiscCatalogRepository) FacetedSearch(ctx context.Context, q CatalogSearchQuery) (*catalog.SearchPage, error) {
+	if q.Limit <= 0 {
+		q.Limit = 20
+	}
+	q.Text = strings.TrimSpace(q.Text)
+
+	mode := catalog.SearchModeNone
+	switch {
+	case q.After != nil:
+		mode = q.After.Mode
+	case textSearchTerms(q.Text) != "":
+		mode = catalog.SearchModeText
+	case q.Text != "":
+		mode = catalog.SearchModeRegex
+	}
+
+	page, err := r.facetedSearch(ctx, q, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	if page.Total == 0 && mode == catalog.SearchModeText && q.After == nil {
+		return r.facetedSearch(ctx, q, catalog.SearchModeRegex)
+	}
+	return page, nil
+}
+
+func (r *MongoDiscCatalogRepository) facetedSearch(ctx context.Context, q CatalogSearchQuery, mode string) (*catalog.SearchPage, error) {
+	cur, err := r.collection.Aggregate(ctx, buildSearchPipeline(q, mode))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var facets searchFacetResult
+	if cur.Next(ctx) {
+		if err := cur.Decode(&facets); err != nil {
+			return nil, err
+		}
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	page := &catalog.SearchPage{
+		Discs: []*catalog.CatalogDisc{},
+		Facets: catalog.SearchFacets{
+			Brands:     facets.Brands,
+			Categories: facets.Categories,
+		},
+	}
+	if len(facets.Total) > 0 {
+		page.Total = facets.Total[0].Count
+	}
+	if page.Facets.Brands == nil {
+		page.Facets.Brands = []catalog.FacetCount{}
+	}
+	if page.Facets.Categories == nil {
+		page.Facets.Categories = []catalog.FacetCount{}
+	}
+
+	for i, result := range facets.Results {
+		if i == q.Limit {
+			last := facets.Results[i-1]
+			page.NextCursor = catalog.SearchCursor{
+				Mode:  mode,
+				Score: last.Score,
+				Name:  last.Name,
+				ID:    last.ID,
+			}.Encode()
+			break
+		}
+		d := result.CatalogDisc
+		page.Discs = append(page.Discs, &d)
+	}
+
+	return page, nil
+}
+
+func buildSearchPipeline(q CatalogSearchQuery, mode string) bson.A {
+	// Filters every branch shares. Brand and category are applied per facet.
+	shared := bson.M{}
+	addRange(shared, "speed", q.Speed)
+	addRange(shared, "glide", q.Glide)
+	addRange(shared, "turn", q.Turn)
+	addRange(shared, "fade", q.Fade)
+	if len(q.StabilitySlugs) > 0 {
+		shared["stability_slug"] = bson.M{"$in": q.StabilitySlugs}
+	}
+
+	pattern := regexp.QuoteMeta(q.Text)
+
+	first := bson.M{}
+	for k, v := range shared {
+		first[k] = v
+	}
+
+	var score any = 0
+	switch mode {
+	case catalog.SearchModeText:
+		first["$text"] = bson.M{"$search": textSearchTerms(q.Text)}
+		score = bson.M{"$meta": "textScore"}
+	case catalog.SearchModeRegex:
+		first["$or"] = textRegexFilter(pattern)
+		score = regexScore(q.Text, pattern)
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": first},
+		bson.M{"$addFields": bson.M{"score": score}},
+	}
+
+	if q.UserID != "" {
+		// Custom discs are matched and scored exactly like the catalog (they
+		// have the same text index) so both rank on one scale.
+		custom := bson.M{"owner_user_id": q.UserID}
+		for k, v := range first {
+			custom[k] = v
+		}
+
+		pipeline = append(pipeline, bson.M{"$unionWith": bson.M{
+			"coll": customCatalogDiscsCollection,
+			"pipeline": bson.A{
+				bson.M{"$match": custom},
+				bson.M{"$addFields": bson.M{"score": score}},
+			},
+		}})
+	}
+
+	brandFilter := bson.M{}
+	if len(q.BrandSlugs) > 0 {
+		brandFilter["brand_slug"] = bson.M{"$in": q.BrandSlugs}
+	}
+	categoryFilter := bson.M{}
+	if len(q.CategorySlugs) > 0 {
+		categoryFilter["category_slug"] = bson.M{"$in": q.CategorySlugs}
+	}
+	both := bson.M{}
+	for k, v := range brandFilter {
+		both[k] = v
+	}
+	for k, v := range categoryFilter {
+		both[k] = v
+	}
+
+	results := bson.A{bson.M{"$match": both}}
+	if q.After != nil {
+		results = append(results, bson.M{"$match": bson.M{"$or": bson.A{
+			bson.M{"score": bson.M{"$lt": q.After.Score}},
+			bson.M{"score": q.After.Score, "name": bson.M{"$gt": q.After.Name}},
+			bson.M{"score": q.After.Score, "name": q.After.Name, "_id": bson.M{"$gt": q.After.ID}},
+		}}})
+	}
+	results = append(results,
+		bson.M{"$sort": bson.D{
+			{Key: "score", Value: -1},
+			{Key: "name", Value: 1},
+			{Key: "_id", Value: 1},
+		}},
+		// One extra tells us whether there is a next page.
+		bson.M{"$limit": int64(q.Limit + 1)},
+	)
+
+	return append(pipeline, bson.M{"$facet": bson.M{
+		"results": results,
+		"total": bson.A{
+			bson.M{"$match": both},
+			bson.M{"$count": "count"},
+		},
+		"brands":     facetPipeline(categoryFilter, "$brand_slug", "$brand"),
+		"categories": facetPipeline(brandFilter, "$category_slug", "$category"),
+	}})
+}
+
+func facetPipeline(filter bson.M, key, label string) bson.A {
+	return bson.A{
+		bson.M{"$match": filter},
+		bson.M{"$group": bson.M{
+			"_id":   key,
+			"label": bson.M{"$first": label},
+			"count": bson.M{"$sum": 1},
+		}},
+		bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+	}
+}
+
+func addRange(filter bson.M, field string, r FloatRange) {
+	cond := bson.M{}
+	if r.Min != nil {
+		cond["$gte"] = *r.Min
+	}
+	if r.Max != nil {
+		cond["$lte"] = *r.Max
+	}
+	if len(cond) > 0 {
+		filter[field] = cond
+	}
+}
+
+func textRegexFilter(pattern string) bson.A {
+	return bson.A{
+		bson.M{"name": bson.M{"$regex": pattern, "$options": "i"}},
+		bson.M{"brand": bson.M{"$regex": pattern, "$options": "i"}},
+	}
+}
+
+// regexScore ranks substring matches: exact name, then name prefix, then
+// anywhere in the name, then brand-only matches.
+func regexScore(text, pattern string) bson.M {
+	nameMatches := func(regex string) bson.M {
+		return bson.M{"$regexMatch": bson.M{"input": "$name", "regex": regex, "options": "i"}}
+	}
+	return bson.M{"$switch": bson.M{
+		"branches": bson.A{
+			bson.M{"case": bson.M{"$eq": bson.A{bson.M{"$toLower": "$name"}, strings.ToLower(text)}}, "then": 3},
+			bson.M{"case": nameMatches("^" + pattern), "then": 2},
+			bson.M{"case": nameMatches(pattern), "then": 1},
+		},
+		"default": 0.5,
+	}}
+}
+
+// textSearchTerms drops $text operators (quotes and leading '-') so user
+// input is always treated as plain words.
+func textSearchTerms(text string) string {
+	words := strings.Fields(strings.ReplaceAll(text, `"`, " "))
+	for i, w := range words {
+		words[i] = strings.TrimLeft(w, "-")
+	}
+	return strings.Join(words, " ")
+}
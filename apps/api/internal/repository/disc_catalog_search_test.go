@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Catalog and custom discs are merged into one ranking, so both branches
+// must match and score the same way in every mode.
+func TestSearchPipelineScoresBranchesAlike(t *testing.T) {
+	speed := 12.0
+	q := CatalogSearchQuery{
+		Text:   "destroyer",
+		UserID: "owner-1",
+		Speed:  FloatRange{Min: &speed},
+		Limit:  20,
+	}
+
+	for _, mode := range []string{catalog.SearchModeNone, catalog.SearchModeText, catalog.SearchModeRegex} {
+		t.Run("mode "+mode, func(t *testing.T) {
+			pipeline := buildSearchPipeline(q, mode)
+
+			match := pipeline[0].(bson.M)["$match"].(bson.M)
+			score := pipeline[1].(bson.M)["$addFields"].(bson.M)["score"]
+
+			union := pipeline[2].(bson.M)["$unionWith"].(bson.M)["pipeline"].(bson.A)
+			customMatch := union[0].(bson.M)["$match"].(bson.M)
+			customScore := union[1].(bson.M)["$addFields"].(bson.M)["score"]
+
+			want := bson.M{"owner_user_id": q.UserID}
+			for k, v := range match {
+				want[k] = v
+			}
+			if !reflect.DeepEqual(customMatch, want) {
+				t.Errorf("custom $match = %v, want %v", customMatch, want)
+			}
+			if !reflect.DeepEqual(customScore, score) {
+				t.Errorf("custom score = %v, catalog score = %v", customScore, score)
+			}
+
+			results := pipeline[3].(bson.M)["$facet"].(bson.M)["results"].(bson.A)
+			sort := results[len(results)-2].(bson.M)["$sort"]
+			wantSort := bson.D{{Key: "score", Value: -1}, {Key: "name", Value: 1}, {Key: "_id", Value: 1}}
+			if !reflect.DeepEqual(sort, wantSort) {
+				t.Errorf("$sort = %v, want %v", sort, wantSort)
+			}
+		})
+	}
+}
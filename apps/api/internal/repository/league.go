@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Tidwell32/zack/apps/api/internal/league"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type LeagueRepository interface {
+	CreateLeague(ctx context.Context, l *league.League) error
+	FindLeagueByID(ctx context.Context, _id bson.ObjectID, userID string) (*league.League, error)
+	FindLeaguesByUserID(ctx context.Context, userID string) ([]*league.League, error)
+	UpdateLeague(ctx context.Context, l *league.League) error
+
+	CreateSeason(ctx context.Context, s *league.Season) error
+	FindSeasonByID(ctx context.Context, _id bson.ObjectID, userID string) (*league.Season, error)
+	FindSeasonsByLeagueID(ctx context.Context, leagueID bson.ObjectID, userID string) ([]*league.Season, error)
+
+	CreateEvent(ctx context.Context, e *league.Event) error
+	FindEventByID(ctx context.Context, _id bson.ObjectID, userID string) (*league.Event, error)
+	FindEventsBySeasonID(ctx context.Context, seasonID bson.ObjectID, userID string) ([]*league.Event, error)
+	UpdateEventResults(ctx context.Context, e *league.Event) error
+}
+
+type MongoLeagueRepository struct {
+	leagues *mongo.Collection
+	seasons *mongo.Collection
+	events  *mongo.Collection
+}
+
+func NewMongoLeagueRepository(leagues, seasons, events *mongo.Collection) LeagueRepository {
+	return &MongoLeagueRepository{
+		leagues: leagues,
+		seasons: seasons,
+		events:  events,
+	}
+}
+
+func (r *MongoLeagueRepository) CreateLeague(ctx context.Context, l *league.League) error {
+	res, err := r.leagues.InsertOne(ctx, l)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		l.ID = oid
+	}
+
+	return nil
+}
+
+func (r *MongoLeagueRepository) FindLeagueByID(ctx context.Context, _id bson.ObjectID, userID string) (*league.League, error) {
+	var result league.League
+	err := r.leagues.FindOne(ctx, bson.M{"_id": _id, "userId": userID}).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *MongoLeagueRepository) FindLeaguesByUserID(ctx context.Context, userID string) ([]*league.League, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cur, err := r.leagues.Find(ctx, bson.M{"userId": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	leagues := []*league.League{}
+	if err := cur.All(ctx, &leagues); err != nil {
+		return nil, err
+	}
+
+	return leagues, nil
+}
+
+func (r *MongoLeagueRepository) UpdateLeague(ctx context.Context, l *league.League) error {
+	filter := bson.M{"_id": l.ID, "userId": l.UserID}
+	update := bson.M{
+		"$set": bson.M{
+			"name":            l.Name,
+			"pointsTable":     l.PointsTable,
+			"payoutTable":     l.PayoutTable,
+			"handicapRounds":  l.HandicapRounds,
+			"handicapPercent": l.HandicapPercent,
+			"updatedAt":       l.UpdatedAt,
+		},
+	}
+
+	_, err := r.leagues.UpdateOne(ctx, filter, update)
+	return err
+}
+
+func (r *MongoLeagueRepository) CreateSeason(ctx context.Context, s *league.Season) error {
+	res, err := r.seasons.InsertOne(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		s.ID = oid
+	}
+
+	return nil
+}
+
+func (r *MongoLeagueRepository) FindSeasonByID(ctx context.Context, _id bson.ObjectID, userID string) (*league.Season, error) {
+	var result league.Season
+	err := r.seasons.FindOne(ctx, bson.M{"_id": _id, "userId": userID}).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *MongoLeagueRepository) FindSeasonsByLeagueID(ctx context.Context, leagueID bson.ObjectID, userID string) ([]*league.Season, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "startDate", Value: -1}})
+
+	cur, err := r.seasons.Find(ctx, bson.M{"leagueId": leagueID, "userId": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	seasons := []*league.Season{}
+	if err := cur.All(ctx, &seasons); err != nil {
+		return nil, err
+	}
+
+	return seasons, nil
+}
+
+func (r *MongoLeagueRepository) CreateEvent(ctx context.Context, e *league.Event) error {
+	res, err := r.events.InsertOne(ctx, e)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		e.ID = oid
+	}
+
+	return nil
+}
+
+func (r *MongoLeagueRepository) FindEventByID(ctx context.Context, _id bson.ObjectID, userID string) (*league.Event, error) {
+	var result league.Event
+	err := r.events.FindOne(ctx, bson.M{"_id": _id, "userId": userID}).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *MongoLeagueRepository) FindEventsBySeasonID(ctx context.Context, seasonID bson.ObjectID, userID string) ([]*league.Event, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
+
+	cur, err := r.events.Find(ctx, bson.M{"seasonId": seasonID, "userId": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	events := []*league.Event{}
+	if err := cur.All(ctx, &events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *MongoLeagueRepository) UpdateEventResults(ctx context.Context, e *league.Event) error {
+	filter := bson.M{"_id": e.ID, "userId": e.UserID}
+	update := bson.M{
+		"$set": bson.M{
+			"teams":     e.Teams,
+			"roundId":   e.RoundID,
+			"results":   e.Results,
+			"scoredAt":  e.ScoredAt,
+			"updatedAt": e.UpdatedAt,
+		},
+	}
+
+	_, err := r.events.UpdateOne(ctx, filter, update)
+	return err
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type PlasticRepository interface {
+	FindByBrand(ctx context.Context, brandSlug string) ([]*catalog.Plastic, error)
+	Search(ctx context.Context, brandSlug, query string, limit int) ([]*catalog.Plastic, error)
+}
+
+type MongoPlasticRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoPlasticRepository(collection *mongo.Collection) PlasticRepository {
+	return &MongoPlasticRepository{
+		collection: collection,
+	}
+}
+
+func (r *MongoPlasticRepository) FindByBrand(ctx context.Context, brandSlug string) ([]*catalog.Plastic, error) {
+	return r.find(ctx, bson.M{"brand_slug": brandSlug}, 0)
+}
+
+// Search matches plastic names and aliases by prefix, optionally within
+// one brand. An empty query lists everything.
+func (r *MongoPlasticRepository) Search(ctx context.Context, brandSlug, query string, limit int) ([]*catalog.Plastic, error) {
+	filter := bson.M{}
+	if brandSlug != "" {
+		filter["brand_slug"] = brandSlug
+	}
+	if query != "" {
+		prefix := bson.M{"$regex": "^" + regexp.QuoteMeta(query), "$options": "i"}
+		filter["$or"] = bson.A{
+			bson.M{"name": prefix},
+			bson.M{"aliases": prefix},
+		}
+	}
+	return r.find(ctx, filter, limit)
+}
+
+func (r *MongoPlasticRepository) find(ctx context.Context, filter bson.M, limit int) ([]*catalog.Plastic, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "brand_slug", Value: 1}, {Key: "name", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cur, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	plastics := []*catalog.Plastic{}
+	for cur.Next(ctx) {
+		var p catalog.Plastic
+		if err := cur.Decode(&p); err != nil {
+			return nil, err
+		}
+		plastics = append(plastics, &p)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return plastics, nil
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// TxRunner runs fn inside a Mongo transaction. Repository calls made with
+// the ctx handed to fn take part in it; fn may be retried on transient
+// errors, so it must not have side effects outside the database.
+type TxRunner interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type MongoTxRunner struct {
+	client *mongo.Client
+}
+
+func NewMongoTxRunner(client *mongo.Client) TxRunner {
+	return &MongoTxRunner{
+		client: client,
+	}
+}
+
+func (r *MongoTxRunner) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := r.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(ctx context.Context) (any, error) {
+		return nil, fn(ctx)
+	})
+	return err
+}
@@ -11,9 +11,14 @@ import (
 )
 
 type UDiscRepository interface {
-	UpsertRounds(ctx context.Context, rounds []udisc.Round) error
+	// Returns the IDs of rounds that did not exist before the upsert
+	UpsertRounds(ctx context.Context, rounds []udisc.Round) ([]bson.ObjectID, error)
+	CreateRound(ctx context.Context, round *udisc.Round) error
+	UpdateRound(ctx context.Context, round *udisc.Round) error
+	DeleteRound(ctx context.Context, _id bson.ObjectID, userID string) error
 	GetRoundsForUser(ctx context.Context, userID string) ([]udisc.Round, error)
 	GetRoundByID(ctx context.Context, _id bson.ObjectID, userID string) (*udisc.Round, error)
+	GetLatestRoundForLayout(ctx context.Context, userID, courseName, layoutName string) (*udisc.Round, error)
 	GetDistinctPlayers(ctx context.Context, userID string) ([]string, error)
 	GetDistinctCourses(ctx context.Context, userID string) ([]udisc.CourseInfo, error)
 }
@@ -28,15 +33,18 @@ func NewMongoUDiscRepository(collection *mongo.Collection) *MongoUDiscRepository
 	}
 }
 
-func (r *MongoUDiscRepository) UpsertRounds(ctx context.Context, rounds []udisc.Round) error {
+func (r *MongoUDiscRepository) UpsertRounds(ctx context.Context, rounds []udisc.Round) ([]bson.ObjectID, error) {
 	if len(rounds) == 0 {
-		return nil
+		return []bson.ObjectID{}, nil
 	}
 
 	var operations []mongo.WriteModel
 
 	for _, round := range rounds {
+		// Manually entered rounds are keyed by _id only, so an import that happens
+		// to share a layout and start time never overwrites them.
 		filter := bson.M{
+			"source":     udisc.RoundSourceImport,
 			"userId":     round.UserID,
 			"courseName": round.CourseName,
 			"layoutName": round.LayoutName,
@@ -45,6 +53,7 @@ func (r *MongoUDiscRepository) UpsertRounds(ctx context.Context, rounds []udisc.
 
 		update := bson.M{
 			"$set": bson.M{
+				"source":     udisc.RoundSourceImport,
 				"userId":     round.UserID,
 				"courseName": round.CourseName,
 				"layoutName": round.LayoutName,
@@ -70,7 +79,63 @@ func (r *MongoUDiscRepository) UpsertRounds(ctx context.Context, rounds []udisc.
 		operations = append(operations, operation)
 	}
 
-	_, err := r.collection.BulkWrite(ctx, operations)
+	res, err := r.collection.BulkWrite(ctx, operations)
+	if err != nil {
+		return nil, err
+	}
+
+	inserted := make([]bson.ObjectID, 0, len(res.UpsertedIDs))
+	for _, id := range res.UpsertedIDs {
+		if oid, ok := id.(bson.ObjectID); ok {
+			inserted = append(inserted, oid)
+		}
+	}
+
+	return inserted, nil
+}
+
+func (r *MongoUDiscRepository) CreateRound(ctx context.Context, round *udisc.Round) error {
+	res, err := r.collection.InsertOne(ctx, round)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		round.ID = oid
+	}
+
+	return nil
+}
+
+func (r *MongoUDiscRepository) UpdateRound(ctx context.Context, round *udisc.Round) error {
+	filter := bson.M{
+		"_id":    round.ID,
+		"userId": round.UserID,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"courseName":  round.CourseName,
+			"layoutName":  round.LayoutName,
+			"startTime":   round.StartTime,
+			"endTime":     round.EndTime,
+			"holeCount":   round.HoleCount,
+			"pars":        round.Pars,
+			"totalPar":    round.TotalPar,
+			"players":     round.Players,
+			"finalizedAt": round.FinalizedAt,
+			"updatedAt":   round.UpdatedAt,
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+func (r *MongoUDiscRepository) DeleteRound(ctx context.Context, _id bson.ObjectID, userID string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{
+		"_id":    _id,
+		"userId": userID,
+	})
 	return err
 }
 
@@ -115,6 +180,28 @@ func (r *MongoUDiscRepository) GetRoundByID(ctx context.Context, _id bson.Object
 	return &round, nil
 }
 
+func (r *MongoUDiscRepository) GetLatestRoundForLayout(ctx context.Context, userID, courseName, layoutName string) (*udisc.Round, error) {
+	filter := bson.M{
+		"userId":     userID,
+		"courseName": courseName,
+		"layoutName": layoutName,
+		"pars.0":     bson.M{"$exists": true},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "startTime", Value: -1}})
+
+	var round udisc.Round
+	err := r.collection.FindOne(ctx, filter, opts).Decode(&round)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &round, nil
+}
+
 func (r *MongoUDiscRepository) GetDistinctPlayers(ctx context.Context, userID string) ([]string, error) {
 	filter := bson.M{"userId": userID}
 
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Tidwell32/zack/apps/api/internal/udisc"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type UDiscAchievementRepository interface {
+	GetForPlayer(ctx context.Context, userID, playerName string) (*udisc.PlayerAchievements, error)
+	Save(ctx context.Context, achievements *udisc.PlayerAchievements) error
+	DeleteForPlayer(ctx context.Context, userID, playerName string) error
+	DeleteForUser(ctx context.Context, userID string) error
+}
+
+type MongoUDiscAchievementRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoUDiscAchievementRepository(collection *mongo.Collection) *MongoUDiscAchievementRepository {
+	return &MongoUDiscAchievementRepository{
+		collection: collection,
+	}
+}
+
+func (r *MongoUDiscAchievementRepository) GetForPlayer(ctx context.Context, userID, playerName string) (*udisc.PlayerAchievements, error) {
+	filter := bson.M{
+		"userId":     userID,
+		"playerName": playerName,
+	}
+
+	var achievements udisc.PlayerAchievements
+	err := r.collection.FindOne(ctx, filter).Decode(&achievements)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &achievements, nil
+}
+
+func (r *MongoUDiscAchievementRepository) Save(ctx context.Context, achievements *udisc.PlayerAchievements) error {
+	filter := bson.M{
+		"userId":     achievements.UserID,
+		"playerName": achievements.PlayerName,
+	}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, achievements, options.Replace().SetUpsert(true))
+	return err
+}
+
+// DeleteForPlayer matches the player name case-insensitively, the same way
+// rounds are matched to players.
+func (r *MongoUDiscAchievementRepository) DeleteForPlayer(ctx context.Context, userID, playerName string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{
+		"userId":     userID,
+		"playerName": playerName,
+	}, options.DeleteMany().SetCollation(&options.Collation{Locale: "en", Strength: 2}))
+	return err
+}
+
+func (r *MongoUDiscAchievementRepository) DeleteForUser(ctx context.Context, userID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"userId": userID})
+	return err
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Tidwell32/zack/apps/api/internal/udisc"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type UDiscPlayerAliasRepository interface {
+	UpsertAlias(ctx context.Context, alias *udisc.PlayerAlias) error
+	RepointAliases(ctx context.Context, userID, fromCanonical, toCanonical string) error
+	GetAliasesForUser(ctx context.Context, userID string) ([]udisc.PlayerAlias, error)
+	DeleteAlias(ctx context.Context, userID, alias string) (bool, error)
+}
+
+type MongoUDiscPlayerAliasRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoUDiscPlayerAliasRepository(collection *mongo.Collection) *MongoUDiscPlayerAliasRepository {
+	return &MongoUDiscPlayerAliasRepository{
+		collection: collection,
+	}
+}
+
+func (r *MongoUDiscPlayerAliasRepository) UpsertAlias(ctx context.Context, alias *udisc.PlayerAlias) error {
+	filter := bson.M{
+		"userId": alias.UserID,
+		"alias":  alias.Alias,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"canonicalName": alias.CanonicalName,
+		},
+		"$setOnInsert": bson.M{
+			"_id":       alias.ID,
+			"createdAt": alias.CreatedAt,
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.UpdateOne().SetUpsert(true))
+	return err
+}
+
+func (r *MongoUDiscPlayerAliasRepository) RepointAliases(ctx context.Context, userID, fromCanonical, toCanonical string) error {
+	filter := bson.M{
+		"userId":        userID,
+		"canonicalName": fromCanonical,
+	}
+	update := bson.M{
+		"$set": bson.M{"canonicalName": toCanonical},
+	}
+
+	_, err := r.collection.UpdateMany(ctx, filter, update)
+	return err
+}
+
+func (r *MongoUDiscPlayerAliasRepository) GetAliasesForUser(ctx context.Context, userID string) ([]udisc.PlayerAlias, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "canonicalName", Value: 1}, {Key: "alias", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"userId": userID}, opts)
+	if err != nil {
+		return []udisc.PlayerAlias{}, err
+	}
+	defer cursor.Close(ctx)
+
+	aliases := []udisc.PlayerAlias{}
+	if err := cursor.All(ctx, &aliases); err != nil {
+		return []udisc.PlayerAlias{}, err
+	}
+
+	return aliases, nil
+}
+
+func (r *MongoUDiscPlayerAliasRepository) DeleteAlias(ctx context.Context, userID, alias string) (bool, error) {
+	res, err := r.collection.DeleteOne(ctx, bson.M{
+		"userId": userID,
+		"alias":  alias,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return res.DeletedCount > 0, nil
+}
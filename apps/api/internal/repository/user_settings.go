@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Tidwell32/zack/apps/api/internal/settings"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type UserSettingsRepository interface {
+	FindByUserID(ctx context.Context, userID string) (*settings.UserSettings, error)
+	Save(ctx context.Context, s *settings.UserSettings) error
+}
+
+type MongoUserSettingsRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoUserSettingsRepository(collection *mongo.Collection) UserSettingsRepository {
+	return &MongoUserSettingsRepository{
+		collection: collection,
+	}
+}
+
+func (r *MongoUserSettingsRepository) FindByUserID(ctx context.Context, userID string) (*settings.UserSettings, error) {
+	var result settings.UserSettings
+	err := r.collection.FindOne(ctx, bson.M{"userId": userID}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Save upserts the user's settings document, keyed by user ID.
+func (r *MongoUserSettingsRepository) Save(ctx context.Context, s *settings.UserSettings) error {
+	update := bson.M{
+		"$set": bson.M{
+			"suggest":   s.Suggest,
+			"updatedAt": s.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{
+			"createdAt": s.CreatedAt,
+		},
+	}
+
+	var saved settings.UserSettings
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"userId": s.UserID},
+		update,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&saved)
+	if err != nil {
+		return err
+	}
+
+	*s = saved
+	return nil
+}
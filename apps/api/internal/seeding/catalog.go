@@ -2,15 +2,10 @@ package seeding
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
 	"strconv"
-	"time"
 
-	"github.com/Tidwell32/zack/apps/api/internal/catalog"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
@@ -37,9 +32,12 @@ type DiscItDisc struct {
 	BgColor       string `json:"background_color"`
 }
 
-// SeedDiscCatalog seeds the disc catalog from DiscIt API
-// If forceReseed is true, it will delete existing data and re-fetch
-func SeedDiscCatalog(ctx context.Context, db *mongo.Database, forceReseed bool) error {
+// SeedDiscCatalog seeds the disc catalog from the given source when it is
+// empty, or when it was last seeded from a partial source (the bundled
+// snapshot after a failed download) and source may be complete. With
+// forceReseed it syncs an existing catalog in place; entries are updated,
+// never deleted and re-inserted.
+func SeedDiscCatalog(ctx context.Context, db *mongo.Database, source CatalogSource, forceReseed bool) error {
 	collection := db.Collection("disc_catalog")
 
 	// Check if already seeded
@@ -49,95 +47,23 @@ func SeedDiscCatalog(ctx context.Context, db *mongo.Database, forceReseed bool)
 	}
 
 	if count > 0 && !forceReseed {
-		log.Printf("📚 Disc catalog already contains %d discs, skipping seed", count)
-		return nil
-	}
-
-	if forceReseed && count > 0 {
-		log.Printf("🔄 Force re-seed enabled, deleting %d existing discs...", count)
-		if _, err := collection.DeleteMany(ctx, bson.M{}); err != nil {
-			return fmt.Errorf("delete existing discs: %w", err)
+		state, err := loadSyncState(ctx, db)
+		if err != nil {
+			return fmt.Errorf("load sync state: %w", err)
 		}
-		log.Println("✅ Existing catalog cleared")
-	}
-
-	log.Println("📥 Fetching disc catalog from DiscIt API...")
-
-	// Fetch from DiscIt API
-	discs, err := fetchDiscItCatalog()
-	if err != nil {
-		return fmt.Errorf("fetch catalog: %w", err)
-	}
-
-	log.Printf("🔄 Processing %d discs...", len(discs))
-
-	// Convert to catalog format
-	catalogDiscs := make([]interface{}, 0, len(discs))
-	for _, d := range discs {
-		catalogDisc := catalog.CatalogDisc{
-			DiscItID:      d.ID,
-			Name:          d.Name,
-			Brand:         d.Brand,
-			Category:      d.Category,
-			Speed:         parseFloat(d.Speed),
-			Glide:         parseFloat(d.Glide),
-			Turn:          parseFloat(d.Turn),
-			Fade:          parseFloat(d.Fade),
-			Stability:     d.Stability,
-			Link:          d.Link,
-			Pic:           d.Pic,
-			NameSlug:      d.NameSlug,
-			BrandSlug:     d.BrandSlug,
-			CategorySlug:  d.CategorySlug,
-			StabilitySlug: d.StabilitySlug,
-			Color:         d.Color,
-			BgColor:       d.BgColor,
+		if state == nil || !state.Partial || isPartial(source) {
+			log.Printf("📚 Disc catalog already contains %d discs, skipping seed", count)
+			return nil
 		}
-		catalogDiscs = append(catalogDiscs, catalogDisc)
-	}
-
-	// Insert into MongoDB
-	log.Println("💾 Inserting discs into database...")
-	result, err := collection.InsertMany(ctx, catalogDiscs)
-	if err != nil {
-		return fmt.Errorf("insert many: %w", err)
-	}
-
-	log.Printf("✅ Inserted %d discs into catalog", len(result.InsertedIDs))
-
-	// Create indexes
-	log.Println("🔍 Creating indexes...")
-	if err := createIndexes(ctx, collection); err != nil {
-		return fmt.Errorf("create indexes: %w", err)
+		log.Printf("🔁 Disc catalog was seeded from %s, retrying %s", state.Source, source.Name())
 	}
 
-	return nil
-}
-
-func fetchDiscItCatalog() ([]DiscItDisc, error) {
-	url := "https://discit-api.fly.dev/disc"
-
-	client := &http.Client{
-		Timeout: 30 * time.Second,
+	if count > 0 {
+		log.Printf("🔄 Force re-seed enabled, syncing %d existing discs...", count)
 	}
 
-	resp, err := client.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("http get: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("api returned status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var discs []DiscItDisc
-	if err := json.NewDecoder(resp.Body).Decode(&discs); err != nil {
-		return nil, fmt.Errorf("decode json: %w", err)
-	}
-
-	return discs, nil
+	_, err = SyncDiscCatalog(ctx, db, source)
+	return err
 }
 
 func parseFloat(s string) float64 {
@@ -0,0 +1,74 @@
+package seeding
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// plasticsJSON is the bundled plastics registry, used when PLASTICS_FILE
+// isn't set.
+//
+//go:embed snapshot/plastics.json
+var plasticsJSON []byte
+
+// SeedPlastics upserts the plastics registry from a JSON file, or from the
+// bundled registry when path is empty. Blends are keyed by brand and name
+// slug, so re-running it picks up edits; blends missing from the file are
+// left alone.
+func SeedPlastics(ctx context.Context, db *mongo.Database, path string) (int, error) {
+	data, name := plasticsJSON, "bundled plastics registry"
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return 0, fmt.Errorf("read plastics file: %w", err)
+		}
+		name = path
+	}
+
+	var plastics []catalog.Plastic
+	if err := json.Unmarshal(data, &plastics); err != nil {
+		return 0, fmt.Errorf("decode %s: %w", name, err)
+	}
+
+	log.Printf("🧪 Seeding %d plastics from %s...", len(plastics), name)
+
+	seen := make(map[string]bool, len(plastics))
+	writes := make([]mongo.WriteModel, 0, len(plastics))
+	for i := range plastics {
+		p := &plastics[i]
+		if err := p.Normalize(); err != nil {
+			return 0, err
+		}
+
+		key := p.BrandSlug + "/" + p.Slug
+		if seen[key] {
+			return 0, fmt.Errorf("%w: %s %s is listed twice", catalog.ErrInvalidPlastic, p.Brand, p.Name)
+		}
+		seen[key] = true
+
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"brand_slug": p.BrandSlug, "slug": p.Slug}).
+			SetReplacement(p).
+			SetUpsert(true))
+	}
+
+	if len(writes) == 0 {
+		return 0, nil
+	}
+
+	if _, err := db.Collection("plastics").BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return 0, fmt.Errorf("bulk write: %w", err)
+	}
+
+	log.Printf("✅ Plastics registry has %d blends from %s", len(writes), name)
+	return len(writes), nil
+}
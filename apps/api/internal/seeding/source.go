@@ -0,0 +1,198 @@
+package seeding
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Tidwell32/zack/apps/api/internal/config"
+)
+
+const DiscItURL = "https://discit-api.fly.dev/disc"
+
+// Catalog source names accepted by CATALOG_SOURCE.
+const (
+	SourceHTTP     = "http"
+	SourceFile     = "file"
+	SourceEmbedded = "embedded"
+)
+
+// snapshotJSON is a small starter catalog in DiscIt's format, bundled so
+// air-gapped deployments and tests always seed the same discs. Refresh it
+// with `go run ./cmd/catalog-snapshot`.
+//
+//go:embed snapshot/discit.json
+var snapshotJSON []byte
+
+// CatalogSource provides the raw DiscIt catalog.
+type CatalogSource interface {
+	Name() string
+	Fetch(ctx context.Context) ([]DiscItDisc, error)
+}
+
+// partialSource is implemented by sources that may hold only part of the
+// catalog. Molds missing from a partial fetch are not discontinued.
+type partialSource interface {
+	Partial() bool
+}
+
+func isPartial(source CatalogSource) bool {
+	p, ok := source.(partialSource)
+	return ok && p.Partial()
+}
+
+// NewCatalogSource builds the source selected by cfg.CatalogSource. The
+// HTTP source falls back to the bundled snapshot when
+// cfg.CatalogEmbeddedFallback is set.
+func NewCatalogSource(cfg *config.Config) (CatalogSource, error) {
+	switch cfg.CatalogSource {
+	case SourceHTTP, "":
+		var source CatalogSource = NewHTTPCatalogSource(cfg.CatalogSourceURL)
+		if cfg.CatalogEmbeddedFallback {
+			source = &FallbackCatalogSource{Primary: source, Fallback: NewEmbeddedCatalogSource()}
+		}
+		return source, nil
+	case SourceFile:
+		if cfg.CatalogSourceFile == "" {
+			return nil, fmt.Errorf("CATALOG_SOURCE_FILE is required when CATALOG_SOURCE=file")
+		}
+		return NewFileCatalogSource(cfg.CatalogSourceFile), nil
+	case SourceEmbedded:
+		return NewEmbeddedCatalogSource(), nil
+	default:
+		return nil, fmt.Errorf("unknown catalog source %q", cfg.CatalogSource)
+	}
+}
+
+// HTTPCatalogSource downloads the catalog from the DiscIt API (or anything
+// serving the same JSON).
+type HTTPCatalogSource struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPCatalogSource(url string) *HTTPCatalogSource {
+	if url == "" {
+		url = DiscItURL
+	}
+	return &HTTPCatalogSource{
+		url: url,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+func (s *HTTPCatalogSource) Name() string { return s.url }
+
+func (s *HTTPCatalogSource) Fetch(ctx context.Context) ([]DiscItDisc, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http get: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("api returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return decodeDiscItJSON(resp.Body)
+}
+
+// FileCatalogSource reads a DiscIt JSON dump from disk.
+type FileCatalogSource struct {
+	path string
+}
+
+func NewFileCatalogSource(path string) *FileCatalogSource {
+	return &FileCatalogSource{
+		path: path,
+	}
+}
+
+func (s *FileCatalogSource) Name() string { return s.path }
+
+func (s *FileCatalogSource) Fetch(_ context.Context) ([]DiscItDisc, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("open snapshot: %w", err)
+	}
+	defer f.Close()
+
+	return decodeDiscItJSON(f)
+}
+
+// EmbeddedCatalogSource serves the snapshot compiled into the binary.
+type EmbeddedCatalogSource struct{}
+
+func NewEmbeddedCatalogSource() *EmbeddedCatalogSource {
+	return &EmbeddedCatalogSource{}
+}
+
+func (s *EmbeddedCatalogSource) Name() string { return "embedded snapshot" }
+
+// Partial is true: the bundled snapshot is a starter subset of DiscIt.
+func (s *EmbeddedCatalogSource) Partial() bool { return true }
+
+func (s *EmbeddedCatalogSource) Fetch(_ context.Context) ([]DiscItDisc, error) {
+	var discs []DiscItDisc
+	if err := json.Unmarshal(snapshotJSON, &discs); err != nil {
+		return nil, fmt.Errorf("decode embedded snapshot: %w", err)
+	}
+	return discs, nil
+}
+
+// FallbackCatalogSource uses Fallback when Primary fails or is empty.
+type FallbackCatalogSource struct {
+	Primary  CatalogSource
+	Fallback CatalogSource
+
+	usedFallback bool
+}
+
+// Partial reports whether the last Fetch came from a partial source.
+func (s *FallbackCatalogSource) Partial() bool {
+	if s.usedFallback {
+		return isPartial(s.Fallback)
+	}
+	return isPartial(s.Primary)
+}
+
+func (s *FallbackCatalogSource) Name() string {
+	return s.Primary.Name() + " (fallback: " + s.Fallback.Name() + ")"
+}
+
+func (s *FallbackCatalogSource) Fetch(ctx context.Context) ([]DiscItDisc, error) {
+	s.usedFallback = false
+	discs, err := s.Primary.Fetch(ctx)
+	if err == nil && len(discs) > 0 {
+		return discs, nil
+	}
+	if err == nil {
+		err = fmt.Errorf("no discs returned")
+	}
+
+	log.Printf("⚠️  Catalog source %s failed (%v), using %s", s.Primary.Name(), err, s.Fallback.Name())
+	s.usedFallback = true
+	return s.Fallback.Fetch(ctx)
+}
+
+func decodeDiscItJSON(r io.Reader) ([]DiscItDisc, error) {
+	var discs []DiscItDisc
+	if err := json.NewDecoder(r).Decode(&discs); err != nil {
+		return nil, fmt.Errorf("decode json: %w", err)
+	}
+	return discs, nil
+}
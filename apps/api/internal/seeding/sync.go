@@ -0,0 +1,340 @@
+package seeding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// SyncDiscCatalog brings disc_catalog in line with the source without
+// touching any _id, so discs referencing a catalog entry by CatalogDiscID
+// keep resolving. Molds are matched by discit_id, then by brand/name slug
+// (for entries seeded from a snapshot with different IDs). Molds missing
+// from a complete source are flagged discontinued instead of deleted.
+// Catalog overrides are applied on top of the incoming data, so owner
+// corrections are kept rather than reported as changes. New molds and
+// flight-number changes are appended to catalog_changes.
+func SyncDiscCatalog(ctx context.Context, db *mongo.Database, source CatalogSource) (*catalog.SyncResult, error) {
+	collection := db.Collection("disc_catalog")
+
+	log.Printf("📥 Fetching disc catalog from %s...", source.Name())
+	incoming, err := source.Fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch catalog: %w", err)
+	}
+	if len(incoming) == 0 {
+		// An empty payload would discontinue every mold.
+		return nil, fmt.Errorf("catalog source returned no discs")
+	}
+
+	existing, err := loadCatalog(ctx, collection)
+	if err != nil {
+		return nil, fmt.Errorf("load catalog: %w", err)
+	}
+
+	previous, err := loadSyncState(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("load sync state: %w", err)
+	}
+
+	overrides, err := loadOverrides(ctx, db.Collection("catalog_overrides"))
+	if err != nil {
+		return nil, fmt.Errorf("load overrides: %w", err)
+	}
+
+	byDiscItID := make(map[string]*catalog.CatalogDisc, len(existing))
+	bySlug := make(map[string]*catalog.CatalogDisc, len(existing))
+	for _, d := range existing {
+		byDiscItID[d.DiscItID] = d
+		bySlug[d.BrandSlug+"/"+d.NameSlug] = d
+	}
+
+	now := time.Now().UTC()
+	result := &catalog.SyncResult{Changes: []catalog.Change{}}
+	seen := make(map[bson.ObjectID]bool, len(incoming))
+	seenDiscItIDs := make(map[string]bool, len(incoming))
+	var writes, overrideWrites []mongo.WriteModel
+
+	for _, raw := range incoming {
+		if raw.ID == "" || seenDiscItIDs[raw.ID] {
+			continue
+		}
+		seenDiscItIDs[raw.ID] = true
+
+		next := toCatalogDisc(raw)
+
+		current := byDiscItID[next.DiscItID]
+		if current == nil {
+			current = bySlug[next.BrandSlug+"/"+next.NameSlug]
+		}
+		if current != nil && seen[current.ID] {
+			// Two source entries resolved to one stored mold; keep the first.
+			continue
+		}
+
+		if current == nil {
+			next.ID = bson.NewObjectID()
+			writes = append(writes, mongo.NewInsertOneModel().SetDocument(next))
+			result.Added++
+			result.Changes = append(result.Changes, newChange(catalog.ChangeAdded, &next, now))
+			continue
+		}
+
+		seen[current.ID] = true
+		if o, ok := overrides[current.ID]; ok {
+			// Keep DiscIt's values under the override so clearing it restores them.
+			if o.TrackOriginal(&next) {
+				overrideWrites = append(overrideWrites, mongo.NewUpdateOneModel().
+					SetFilter(bson.M{"_id": o.ID}).
+					SetUpdate(bson.M{"$set": bson.M{"original": o.Original}}))
+			}
+			o.Fields.Apply(&next)
+		}
+		fields := changedFields(current, &next)
+
+		if len(fields) == 0 && !current.Discontinued {
+			result.Unchanged++
+			continue
+		}
+
+		update := bson.M{"$set": discItFields(&next)}
+		kind := catalog.ChangeUpdated
+		if current.Discontinued {
+			update["$unset"] = bson.M{"discontinued": "", "discontinued_at": ""}
+			kind = catalog.ChangeRestored
+			result.Restored++
+		} else {
+			result.Updated++
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": current.ID}).
+			SetUpdate(update))
+
+		next.ID = current.ID
+		change := newChange(kind, &next, now)
+		change.Fields = fields
+		if before, after := current.Flight(), next.Flight(); before != after {
+			change.Before, change.After = &before, &after
+		}
+		result.Changes = append(result.Changes, change)
+	}
+
+	partial := isPartial(source)
+	if partial {
+		log.Printf("ℹ️  %s is a partial catalog, not discontinuing missing molds", source.Name())
+	}
+
+	for _, d := range existing {
+		if partial || seen[d.ID] || d.Discontinued {
+			continue
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": d.ID}).
+			SetUpdate(bson.M{"$set": bson.M{"discontinued": true, "discontinued_at": now}}))
+		result.Discontinued++
+		result.Changes = append(result.Changes, newChange(catalog.ChangeDiscontinued, d, now))
+	}
+
+	if len(writes) > 0 {
+		log.Printf("💾 Writing %d catalog changes...", len(writes))
+		if _, err := collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+			return nil, fmt.Errorf("bulk write: %w", err)
+		}
+	}
+
+	if len(overrideWrites) > 0 {
+		if _, err := db.Collection("catalog_overrides").BulkWrite(ctx, overrideWrites, options.BulkWrite().SetOrdered(false)); err != nil {
+			return nil, fmt.Errorf("update override originals: %w", err)
+		}
+	}
+
+	if err := createIndexes(ctx, collection); err != nil {
+		return nil, fmt.Errorf("create indexes: %w", err)
+	}
+
+	// The first load of an empty catalog isn't news, and neither is filling
+	// in the rest of a partial one; only record history once there was a
+	// complete catalog to compare against.
+	if len(existing) > 0 && (previous == nil || !previous.Partial) {
+		if err := recordChanges(ctx, db.Collection("catalog_changes"), result.HistoryChanges()); err != nil {
+			return nil, fmt.Errorf("record changes: %w", err)
+		}
+	}
+
+	state := &syncState{ID: syncStateID, Source: source.Name(), Partial: partial, SyncedAt: now}
+	if err := saveSyncState(ctx, db, state); err != nil {
+		return nil, fmt.Errorf("save sync state: %w", err)
+	}
+
+	logSyncResult(result)
+	return result, nil
+}
+
+const syncStateID = "disc_catalog"
+
+// syncState records where the catalog last came from, so a catalog seeded
+// from a partial source is re-synced from the full one on a later boot.
+type syncState struct {
+	ID       string    `bson:"_id"`
+	Source   string    `bson:"source"`
+	Partial  bool      `bson:"partial"`
+	SyncedAt time.Time `bson:"synced_at"`
+}
+
+func loadSyncState(ctx context.Context, db *mongo.Database) (*syncState, error) {
+	var state syncState
+	err := db.Collection("catalog_sync_state").FindOne(ctx, bson.M{"_id": syncStateID}).Decode(&state)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func saveSyncState(ctx context.Context, db *mongo.Database, state *syncState) error {
+	_, err := db.Collection("catalog_sync_state").ReplaceOne(ctx,
+		bson.M{"_id": state.ID}, state, options.Replace().SetUpsert(true))
+	return err
+}
+
+func loadCatalog(ctx context.Context, collection *mongo.Collection) ([]*catalog.CatalogDisc, error) {
+	cur, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var discs []*catalog.CatalogDisc
+	if err := cur.All(ctx, &discs); err != nil {
+		return nil, err
+	}
+	return discs, nil
+}
+
+func recordChanges(ctx context.Context, collection *mongo.Collection, changes []catalog.Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	docs := make([]any, len(changes))
+	for i, c := range changes {
+		docs[i] = c
+	}
+	if _, err := collection.InsertMany(ctx, docs); err != nil {
+		return err
+	}
+
+	log.Printf("📝 Recorded %d catalog changes", len(changes))
+	return nil
+}
+
+func loadOverrides(ctx context.Context, collection *mongo.Collection) (map[bson.ObjectID]*catalog.Override, error) {
+	cur, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var overrides []catalog.Override
+	if err := cur.All(ctx, &overrides); err != nil {
+		return nil, err
+	}
+
+	byCatalogID := make(map[bson.ObjectID]*catalog.Override, len(overrides))
+	for i := range overrides {
+		byCatalogID[overrides[i].CatalogID] = &overrides[i]
+	}
+	return byCatalogID, nil
+}
+
+func toCatalogDisc(d DiscItDisc) catalog.CatalogDisc {
+	return catalog.CatalogDisc{
+		DiscItID:      d.ID,
+		Name:          d.Name,
+		Brand:         d.Brand,
+		Category:      d.Category,
+		Speed:         parseFloat(d.Speed),
+		Glide:         parseFloat(d.Glide),
+		Turn:          parseFloat(d.Turn),
+		Fade:          parseFloat(d.Fade),
+		Stability:     d.Stability,
+		Link:          d.Link,
+		Pic:           d.Pic,
+		NameSlug:      d.NameSlug,
+		BrandSlug:     d.BrandSlug,
+		CategorySlug:  d.CategorySlug,
+		StabilitySlug: d.StabilitySlug,
+		Color:         d.Color,
+		BgColor:       d.BgColor,
+	}
+}
+
+// discItFields are the stored fields owned by DiscIt; everything else on
+// a catalog document is ours and survives a sync.
+func discItFields(d *catalog.CatalogDisc) bson.M {
+	return bson.M{
+		"discit_id":        d.DiscItID,
+		"name":             d.Name,
+		"brand":            d.Brand,
+		"category":         d.Category,
+		"speed":            d.Speed,
+		"glide":            d.Glide,
+		"turn":             d.Turn,
+		"fade":             d.Fade,
+		"stability":        d.Stability,
+		"link":             d.Link,
+		"pic":              d.Pic,
+		"name_slug":        d.NameSlug,
+		"brand_slug":       d.BrandSlug,
+		"category_slug":    d.CategorySlug,
+		"stability_slug":   d.StabilitySlug,
+		"color":            d.Color,
+		"background_color": d.BgColor,
+	}
+}
+
+func changedFields(old, next *catalog.CatalogDisc) []string {
+	oldFields, nextFields := discItFields(old), discItFields(next)
+
+	var fields []string
+	for _, key := range slices.Sorted(maps.Keys(nextFields)) {
+		if oldFields[key] != nextFields[key] {
+			fields = append(fields, key)
+		}
+	}
+	return fields
+}
+
+func newChange(kind string, d *catalog.CatalogDisc, at time.Time) catalog.Change {
+	return catalog.Change{
+		Kind:      kind,
+		At:        at,
+		CatalogID: d.ID,
+		DiscItID:  d.DiscItID,
+		Name:      d.Name,
+		Brand:     d.Brand,
+		BrandSlug: d.BrandSlug,
+	}
+}
+
+func logSyncResult(result *catalog.SyncResult) {
+	log.Printf("✅ Catalog sync: %d added, %d updated, %d restored, %d discontinued, %d unchanged",
+		result.Added, result.Updated, result.Restored, result.Discontinued, result.Unchanged)
+
+	for _, c := range result.FlightChanges() {
+		log.Printf("✈️  %s %s: %g/%g/%g/%g -> %g/%g/%g/%g", c.Brand, c.Name,
+			c.Before.Speed, c.Before.Glide, c.Before.Turn, c.Before.Fade,
+			c.After.Speed, c.After.Glide, c.After.Turn, c.After.Fade)
+	}
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+
+	"github.com/Tidwell32/zack/apps/api/internal/bag"
+	"github.com/Tidwell32/zack/apps/api/internal/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// loadBagWithAccess is the single place bag authorization is decided. Callers
+// who cannot even view the bag get ErrNotFound so private bags don't reveal
+// that they exist; viewers asking for owner access get ErrForbidden.
+func loadBagWithAccess(
+	ctx context.Context,
+	repo repository.BagRepository,
+	bagID bson.ObjectID,
+	callerID string,
+	need bag.Access,
+) (*bag.Bag, error) {
+	b, err := repo.FindByID(ctx, bagID)
+	if err != nil {
+		return nil, err
+	}
+
+	if b == nil {
+		return nil, ErrNotFound
+	}
+
+	access := b.AccessFor(callerID)
+	if access == bag.AccessNone {
+		return nil, ErrNotFound
+	}
+	if access < need {
+		return nil, ErrForbidden
+	}
+
+	return b, nil
+}
@@ -2,22 +2,45 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/Tidwell32/zack/apps/api/internal/bag"
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+	"github.com/Tidwell32/zack/apps/api/internal/disc"
 	"github.com/Tidwell32/zack/apps/api/internal/repository"
+	"github.com/Tidwell32/zack/apps/api/internal/storage"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
 type BagService struct {
-	repo     repository.BagRepository
-	discRepo repository.DiscRepository
+	repo         repository.BagRepository
+	discRepo     repository.DiscRepository
+	catalog      *CatalogService
+	templateRepo repository.BagTemplateRepository
+	tx           repository.TxRunner
+	blobs        storage.BlobStore
 }
 
-func NewBagService(repo repository.BagRepository, discRepo repository.DiscRepository) *BagService {
+func NewBagService(
+	repo repository.BagRepository,
+	discRepo repository.DiscRepository,
+	catalogSvc *CatalogService,
+	templateRepo repository.BagTemplateRepository,
+	tx repository.TxRunner,
+	blobs storage.BlobStore,
+) *BagService {
 	return &BagService{
-		repo:     repo,
-		discRepo: discRepo,
+		repo:         repo,
+		discRepo:     discRepo,
+		catalog:      catalogSvc,
+		templateRepo: templateRepo,
+		tx:           tx,
+		blobs:        blobs,
 	}
 }
 
@@ -28,11 +51,18 @@ func (s *BagService) CreateBag(
 ) (*bag.Bag, bool, error) {
 	now := time.Now().UTC()
 
+	visibility := input.Visibility
+	if visibility == "" {
+		visibility = bag.VisibilityPublic
+	}
+
 	b := &bag.Bag{
-		ID:        bson.NewObjectID(),
-		Name:      input.Name,
-		CreatedAt: now,
-		UpdatedAt: now,
+		ID:         bson.NewObjectID(),
+		Name:       input.Name,
+		Visibility: visibility,
+		ViewerIDs:  input.ViewerIDs,
+		CreatedAt:  now,
+		UpdatedAt:  now,
 	}
 
 	if userID != nil {
@@ -46,33 +76,30 @@ func (s *BagService) CreateBag(
 	return b, false, nil
 }
 
-func (s *BagService) GetBagByID(ctx context.Context, id bson.ObjectID) (*bag.Bag, error) {
-	b, err := s.repo.FindByID(ctx, id)
+// GetBagsForUser lists ownerID's bags that callerID is allowed to see.
+// Only the owner gets the viewer lists.
+func (s *BagService) GetBagsForUser(ctx context.Context, ownerID, callerID string) ([]*bag.Bag, error) {
+	bags, err := s.repo.FindByUserID(ctx, ownerID)
 	if err != nil {
 		return nil, err
 	}
 
-	if b == nil {
-		return nil, ErrNotFound
+	visible := make([]*bag.Bag, 0, len(bags))
+	for _, b := range bags {
+		if b.AccessFor(callerID) != bag.AccessNone {
+			visible = append(visible, b.ForCaller(callerID))
+		}
 	}
 
-	return b, nil
-}
-
-func (s *BagService) GetBagsForUser(ctx context.Context, userID string) ([]*bag.Bag, error) {
-	return s.repo.FindByUserID(ctx, userID)
+	return visible, nil
 }
 
-func (s *BagService) GetBagWithDiscs(ctx context.Context, id bson.ObjectID) (*bag.BagWithDiscs, error) {
-	b, err := s.repo.FindByID(ctx, id)
+func (s *BagService) GetBagWithDiscs(ctx context.Context, callerID string, id bson.ObjectID) (*bag.BagWithDiscs, error) {
+	b, err := loadBagWithAccess(ctx, s.repo, id, callerID, bag.AccessViewer)
 	if err != nil {
 		return nil, err
 	}
 
-	if b == nil {
-		return nil, ErrNotFound
-	}
-
 	discs, err := s.discRepo.FindByBagID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -84,11 +111,48 @@ func (s *BagService) GetBagWithDiscs(ctx context.Context, id bson.ObjectID) (*ba
 	}
 
 	return &bag.BagWithDiscs{
-		Bag:   b,
+		Bag:   b.ForCaller(callerID),
 		Discs: discInterfaces,
 	}, nil
 }
 
+// AnalyzeGaps maps the bag onto the flight chart and suggests catalog discs
+// for each empty slot using the bag owner's suggestion preferences. Molds
+// the owner already has anywhere are skipped.
+func (s *BagService) AnalyzeGaps(ctx context.Context, callerID string, bagID bson.ObjectID, limit int) (*bag.GapAnalysis, error) {
+	b, err := loadBagWithAccess(ctx, s.repo, bagID, callerID, bag.AccessViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	discs, err := s.discRepo.FindByBagID(ctx, bagID)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := bag.AnalyzeCoverage(bagID, discs)
+
+	for i := range analysis.Gaps {
+		gap := &analysis.Gaps[i]
+
+		candidates, err := s.catalog.Suggest(ctx, b.UserID, repository.SuggestCriteria{
+			MinSpeed:     gap.Speed.Min,
+			MaxSpeed:     gap.Speed.Max,
+			MinStability: gap.Stability.Min,
+			MaxStability: gap.Stability.Max,
+			ExcludeOwned: true,
+			Limit:        limit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to suggest discs for %s %s: %w", gap.Stability.Label, gap.Speed.Label, err)
+		}
+
+		gap.Suggestions = append(gap.Suggestions, candidates...)
+	}
+
+	return analysis, nil
+}
+
 func (s *BagService) UpdateBag(
 	ctx context.Context,
 	userID *string,
@@ -98,23 +162,22 @@ func (s *BagService) UpdateBag(
 	now := time.Now().UTC()
 
 	if userID == nil {
-		return &bag.Bag{
-			ID:        bagID,
-			Name:      input.Name,
-			UpdatedAt: now,
-			CreatedAt: now,
-		}, false, nil
+		b := &bag.Bag{
+			ID:         bagID,
+			Visibility: bag.VisibilityPublic,
+			UpdatedAt:  now,
+			CreatedAt:  now,
+		}
+		applyBagUpdate(b, input)
+		return b, false, nil
 	}
 
-	existing, err := s.repo.FindByID(ctx, bagID)
+	existing, err := loadBagWithAccess(ctx, s.repo, bagID, *userID, bag.AccessOwner)
 	if err != nil {
 		return nil, false, err
 	}
-	if existing == nil {
-		return nil, false, ErrNotFound
-	}
 
-	existing.Name = input.Name
+	applyBagUpdate(existing, input)
 	existing.UpdatedAt = now
 
 	if err := s.repo.Update(ctx, existing); err != nil {
@@ -124,17 +187,552 @@ func (s *BagService) UpdateBag(
 	return existing, true, nil
 }
 
+func applyBagUpdate(b *bag.Bag, input bag.UpdateBagInput) {
+	if input.Name != nil {
+		b.Name = *input.Name
+	}
+	if input.Visibility != nil {
+		b.Visibility = *input.Visibility
+	}
+	if input.ViewerIDs != nil {
+		b.ViewerIDs = *input.ViewerIDs
+	}
+}
+
+// DeleteBag removes the bag and, in the same transaction, deals with its
+// discs according to opts.Mode: move them to another bag, put them on the
+// backup shelf, or delete them and their photos. Discs only linked to the
+// bag (lost, sold, ...) are kept and detached in every mode.
 func (s *BagService) DeleteBag(
 	ctx context.Context,
 	userID *string,
 	bagID bson.ObjectID,
-) (bool, error) {
-	if userID != nil {
-		if err := s.repo.Delete(ctx, bagID); err != nil {
-			return false, err
+	opts bag.DeleteOptions,
+) (*bag.DeleteSummary, error) {
+	summary := &bag.DeleteSummary{
+		BagID:       bagID,
+		Mode:        opts.Mode,
+		TargetBagID: opts.TargetBagID,
+	}
+
+	if userID == nil {
+		return summary, nil
+	}
+
+	if _, err := loadBagWithAccess(ctx, s.repo, bagID, *userID, bag.AccessOwner); err != nil {
+		return nil, err
+	}
+
+	if opts.Mode == bag.DeleteModeMove {
+		if *opts.TargetBagID == bagID {
+			return nil, ErrInvalidDeleteTarget
+		}
+		if _, err := loadBagWithAccess(ctx, s.repo, *opts.TargetBagID, *userID, bag.AccessOwner); err != nil {
+			return nil, err
+		}
+	}
+
+	var photos []disc.Photo
+	err := s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		// fn may be retried, so start every attempt from zero.
+		summary.DiscsMoved, summary.DiscsShelved, summary.DiscsDeleted, summary.DiscsDetached = 0, 0, 0, 0
+		photos = nil
+
+		var err error
+		switch opts.Mode {
+		case bag.DeleteModeMove:
+			summary.DiscsMoved, err = s.discRepo.ReassignBag(ctx, bagID, *opts.TargetBagID)
+		case bag.DeleteModeDelete:
+			if photos, err = s.inBagPhotos(ctx, bagID); err != nil {
+				return err
+			}
+			summary.DiscsDeleted, err = s.discRepo.DeleteInBag(ctx, bagID)
+		default:
+			summary.DiscsShelved, err = s.discRepo.ShelveBag(ctx, bagID, shelvedChange("bag deleted"))
+		}
+		if err != nil {
+			return err
+		}
+
+		if summary.DiscsDetached, err = s.discRepo.DetachBag(ctx, bagID); err != nil {
+			return err
+		}
+
+		return s.repo.Delete(ctx, bagID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Blobs aren't part of the transaction, so they go only once it has
+	// committed.
+	for _, p := range photos {
+		deletePhotoBlobs(ctx, s.blobs, p)
+	}
+
+	return summary, nil
+}
+
+// inBagPhotos lists the photos of the discs DeleteInBag would remove.
+func (s *BagService) inBagPhotos(ctx context.Context, bagID bson.ObjectID) ([]disc.Photo, error) {
+	discs, err := s.discRepo.FindByBagID(ctx, bagID)
+	if err != nil {
+		return nil, err
+	}
+
+	var photos []disc.Photo
+	for _, d := range discs {
+		if d.Status == disc.StatusInBag {
+			photos = append(photos, d.Photos...)
+		}
+	}
+	return photos, nil
+}
+
+// RepairOrphans finds discs still pointing at bags that no longer exist
+// (left behind by deletes before they cascaded) and shelves them. With
+// dryRun set it only reports what would change.
+func (s *BagService) RepairOrphans(ctx context.Context, dryRun bool) (*bag.OrphanReport, error) {
+	bagIDs, err := s.discRepo.DistinctBagIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &bag.OrphanReport{DryRun: dryRun, Bags: []bag.OrphanedBag{}}
+	for _, bagID := range bagIDs {
+		b, err := s.repo.FindByID(ctx, bagID)
+		if err != nil {
+			return nil, err
+		}
+		if b != nil {
+			continue
+		}
+
+		orphan := bag.OrphanedBag{BagID: bagID}
+		if dryRun {
+			inBag, linked, err := s.discRepo.CountByBagID(ctx, bagID)
+			if err != nil {
+				return nil, err
+			}
+			orphan.DiscsShelved, orphan.DiscsDetached = inBag, linked
+		} else {
+			err := s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+				var err error
+				if orphan.DiscsShelved, err = s.discRepo.ShelveBag(ctx, bagID, shelvedChange("bag no longer exists")); err != nil {
+					return err
+				}
+				orphan.DiscsDetached, err = s.discRepo.DetachBag(ctx, bagID)
+				return err
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to repair discs of bag %s: %w", bagID.Hex(), err)
+			}
+		}
+
+		report.Bags = append(report.Bags, orphan)
+	}
+
+	return report, nil
+}
+
+func shelvedChange(note string) disc.StatusChange {
+	return disc.StatusChange{
+		Status:    disc.StatusBackup,
+		ChangedAt: time.Now().UTC(),
+		Note:      note,
+	}
+}
+
+// CreateShareToken adds a read-only share link to the bag. Expired tokens
+// are pruned while we're writing anyway.
+func (s *BagService) CreateShareToken(
+	ctx context.Context,
+	userID string,
+	bagID bson.ObjectID,
+	expiresAt *time.Time,
+) (*bag.ShareToken, error) {
+	b, err := loadBagWithAccess(ctx, s.repo, bagID, userID, bag.AccessOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	share := bag.ShareToken{
+		Token:     token,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}
+
+	tokens := append(activeShareTokens(b.ShareTokens, now), share)
+	if err := s.repo.SetShareTokens(ctx, bagID, tokens); err != nil {
+		return nil, err
+	}
+
+	return &share, nil
+}
+
+func (s *BagService) GetShareTokens(ctx context.Context, userID string, bagID bson.ObjectID) ([]bag.ShareToken, error) {
+	b, err := loadBagWithAccess(ctx, s.repo, bagID, userID, bag.AccessOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	return activeShareTokens(b.ShareTokens, time.Now().UTC()), nil
+}
+
+func (s *BagService) RevokeShareToken(ctx context.Context, userID string, bagID bson.ObjectID, token string) error {
+	b, err := loadBagWithAccess(ctx, s.repo, bagID, userID, bag.AccessOwner)
+	if err != nil {
+		return err
+	}
+
+	kept := make([]bag.ShareToken, 0, len(b.ShareTokens))
+	found := false
+	for _, t := range b.ShareTokens {
+		if t.Token == token {
+			found = true
+			continue
+		}
+		kept = append(kept, t)
+	}
+
+	if !found {
+		return ErrNotFound
+	}
+
+	return s.repo.SetShareTokens(ctx, bagID, kept)
+}
+
+// GetSharedBag resolves a share link. Unknown, revoked and expired tokens
+// all look the same to the caller.
+func (s *BagService) GetSharedBag(ctx context.Context, token string) (*bag.SharedBag, error) {
+	b, err := s.repo.FindByShareToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if b == nil {
+		return nil, ErrNotFound
+	}
+
+	now := time.Now().UTC()
+	var share *bag.ShareToken
+	for i := range b.ShareTokens {
+		if b.ShareTokens[i].Token == token {
+			share = &b.ShareTokens[i]
+			break
 		}
-		return true, nil
 	}
 
-	return false, nil
+	if share == nil || share.IsExpired(now) {
+		return nil, ErrNotFound
+	}
+
+	discs, err := s.discRepo.FindByBagID(ctx, b.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return bag.NewSharedBag(b, *share, discs), nil
+}
+
+func activeShareTokens(tokens []bag.ShareToken, now time.Time) []bag.ShareToken {
+	active := make([]bag.ShareToken, 0, len(tokens))
+	for _, t := range tokens {
+		if !t.IsExpired(now) {
+			active = append(active, t)
+		}
+	}
+	return active
+}
+
+func newShareToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func (s *BagService) ExportBag(ctx context.Context, callerID string, bagID bson.ObjectID) (*bag.Export, error) {
+	b, err := loadBagWithAccess(ctx, s.repo, bagID, callerID, bag.AccessViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	discs, err := s.discRepo.FindByBagID(ctx, bagID)
+	if err != nil {
+		return nil, err
+	}
+
+	return bag.NewExport(b, discs, b.AccessFor(callerID) == bag.AccessOwner), nil
+}
+
+// ImportBag matches each disc in the file against the catalog and creates a
+// new private bag holding the ones that matched. Discs that don't match are
+// reported back instead of failing the import.
+func (s *BagService) ImportBag(ctx context.Context, userID string, file *bag.ImportFile, name string) (*bag.ImportResult, error) {
+	result := &bag.ImportResult{
+		Imported:  []bag.ImportMatch{},
+		Unmatched: []bag.ImportUnmatched{},
+	}
+
+	type matched struct {
+		imported bag.ImportedDisc
+		catalog  *catalog.CatalogDisc
+	}
+	var toCreate []matched
+
+	for _, d := range file.Discs {
+		if d.Name == "" {
+			result.Unmatched = append(result.Unmatched, bag.ImportUnmatched{
+				Row: d.Row, Brand: d.Brand, Name: d.Name, Reason: "missing disc name",
+			})
+			continue
+		}
+
+		m, err := s.catalog.matchDisc(ctx, d.Brand, d.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to match %s %s: %w", d.Brand, d.Name, err)
+		}
+		if m == nil {
+			result.Unmatched = append(result.Unmatched, bag.ImportUnmatched{
+				Row: d.Row, Brand: d.Brand, Name: d.Name, Reason: "no matching catalog disc",
+			})
+			continue
+		}
+
+		toCreate = append(toCreate, matched{imported: d, catalog: m.disc})
+		result.Imported = append(result.Imported, bag.ImportMatch{
+			Row:           d.Row,
+			Brand:         d.Brand,
+			Name:          d.Name,
+			CatalogDiscID: m.disc.ID.Hex(),
+			MatchedBrand:  m.disc.Brand,
+			MatchedName:   m.disc.Name,
+			MatchType:     m.matchType,
+			Score:         math.Round(m.score*100) / 100,
+		})
+	}
+
+	if len(toCreate) == 0 {
+		return result, nil
+	}
+
+	created, err := s.createBagWithDiscs(ctx, userID, firstNonBlank(name, file.Name, "Imported bag"), bag.VisibilityPrivate,
+		func(bagID bson.ObjectID, now time.Time) []*disc.Disc {
+			discs := make([]*disc.Disc, len(toCreate))
+			for i, m := range toCreate {
+				input := disc.CreateDiscInput{
+					Plastic:  m.imported.Plastic,
+					Weight:   m.imported.Weight,
+					ColorHex: m.imported.ColorHex,
+					Notes:    m.imported.Notes,
+				}
+
+				// Only keep the file's numbers when they differ from stock.
+				stock := disc.FlightNumbers{Speed: m.catalog.Speed, Glide: m.catalog.Glide, Turn: m.catalog.Turn, Fade: m.catalog.Fade}
+				if m.imported.Flight != nil && *m.imported.Flight != stock {
+					input.AdjustedFlight = m.imported.Flight
+				}
+
+				discs[i] = newDiscFromCatalog(m.catalog, bagID, input, now)
+				discs[i].UserID = userID
+			}
+			return discs
+		})
+	if err != nil {
+		return nil, err
+	}
+	result.Bag = created.Bag
+
+	return result, nil
+}
+
+// availableBagName appends a counter when the user already has a bag with
+// this name, since names are unique per user.
+func (s *BagService) availableBagName(ctx context.Context, userID, name string) (string, error) {
+	bags, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	taken := make(map[string]bool, len(bags))
+	for _, b := range bags {
+		taken[b.Name] = true
+	}
+
+	candidate := name
+	for i := 2; taken[candidate]; i++ {
+		candidate = fmt.Sprintf("%s (%d)", name, i)
+	}
+	return candidate, nil
+}
+
+func firstNonBlank(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+// CloneBag copies a bag the caller can see, with every disc, into a new bag
+// the caller owns. Notes and visibility only come along when cloning your own
+// bag; a copy of anyone else's starts private.
+func (s *BagService) CloneBag(ctx context.Context, userID string, bagID bson.ObjectID, name string) (*bag.BagWithDiscs, error) {
+	source, err := loadBagWithAccess(ctx, s.repo, bagID, userID, bag.AccessViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	discs, err := s.discRepo.FindByBagID(ctx, bagID)
+	if err != nil {
+		return nil, err
+	}
+
+	isOwner := source.AccessFor(userID) == bag.AccessOwner
+	snapshot := make([]bag.TemplateDisc, len(discs))
+	for i, d := range discs {
+		snapshot[i] = bag.NewTemplateDisc(d, isOwner)
+	}
+
+	visibility := bag.VisibilityPrivate
+	if isOwner && source.Visibility != "" {
+		visibility = source.Visibility
+	}
+
+	return s.createBagWithDiscs(ctx, userID, firstNonBlank(name, source.Name+" (copy)"), visibility, fromSnapshot(userID, snapshot))
+}
+
+// SaveTemplate snapshots a bag under a template name. Saving over an
+// existing name replaces that template.
+func (s *BagService) SaveTemplate(ctx context.Context, userID string, bagID bson.ObjectID, name string) (*bag.Template, error) {
+	if _, err := loadBagWithAccess(ctx, s.repo, bagID, userID, bag.AccessOwner); err != nil {
+		return nil, err
+	}
+
+	discs, err := s.discRepo.FindByBagID(ctx, bagID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	t := &bag.Template{
+		ID:          bson.NewObjectID(),
+		UserID:      userID,
+		Name:        name,
+		SourceBagID: bagID,
+		Discs:       make([]bag.TemplateDisc, len(discs)),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	for i, d := range discs {
+		t.Discs[i] = bag.NewTemplateDisc(d, true)
+	}
+
+	existing, err := s.templateRepo.FindByName(ctx, userID, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		t.ID = existing.ID
+		t.CreatedAt = existing.CreatedAt
+	}
+
+	if err := s.templateRepo.Save(ctx, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *BagService) GetTemplates(ctx context.Context, userID string) ([]*bag.Template, error) {
+	return s.templateRepo.FindByUserID(ctx, userID)
+}
+
+func (s *BagService) DeleteTemplate(ctx context.Context, userID string, templateID bson.ObjectID) error {
+	deleted, err := s.templateRepo.Delete(ctx, templateID, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// InstantiateTemplate creates a new private bag from a template. The bag is
+// named after the template unless a name is given.
+func (s *BagService) InstantiateTemplate(ctx context.Context, userID string, templateID bson.ObjectID, name string) (*bag.BagWithDiscs, error) {
+	t, err := s.templateRepo.FindByID(ctx, templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrNotFound
+	}
+
+	return s.createBagWithDiscs(ctx, userID, firstNonBlank(name, t.Name), bag.VisibilityPrivate, fromSnapshot(userID, t.Discs))
+}
+
+// createBagWithDiscs writes a new bag and the discs newDiscs builds for it in
+// one transaction so a failure never leaves a half-filled bag behind.
+func (s *BagService) createBagWithDiscs(
+	ctx context.Context,
+	userID, name, visibility string,
+	newDiscs func(bagID bson.ObjectID, now time.Time) []*disc.Disc,
+) (*bag.BagWithDiscs, error) {
+	bagName, err := s.availableBagName(ctx, userID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	b := &bag.Bag{
+		ID:         bson.NewObjectID(),
+		UserID:     userID,
+		Name:       bagName,
+		Visibility: visibility,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	discs := newDiscs(b.ID, now)
+
+	err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, b); err != nil {
+			return err
+		}
+		return s.discRepo.CreateMany(ctx, discs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	discInterfaces := make([]interface{}, len(discs))
+	for i, d := range discs {
+		discInterfaces[i] = d
+	}
+
+	return &bag.BagWithDiscs{
+		Bag:   b,
+		Discs: discInterfaces,
+	}, nil
+}
+
+func fromSnapshot(userID string, snapshot []bag.TemplateDisc) func(bson.ObjectID, time.Time) []*disc.Disc {
+	return func(bagID bson.ObjectID, now time.Time) []*disc.Disc {
+		discs := make([]*disc.Disc, len(snapshot))
+		for i, t := range snapshot {
+			discs[i] = t.NewDisc(userID, bagID, now)
+		}
+		return discs
+	}
 }
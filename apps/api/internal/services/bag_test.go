@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Tidwell32/zack/apps/api/internal/bag"
+	"github.com/Tidwell32/zack/apps/api/internal/disc"
+	"github.com/Tidwell32/zack/apps/api/internal/storage"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Viewers and anonymous callers can read a bag but never its viewer list.
+func TestBagViewerIDsOnlyForOwner(t *testing.T) {
+	const (
+		owner  = "owner-1"
+		viewer = "viewer-1"
+	)
+
+	sharedBag := bson.NewObjectID()
+	publicBag := bson.NewObjectID()
+	bags := &fakeBagRepo{bags: map[bson.ObjectID]*bag.Bag{
+		sharedBag: {ID: sharedBag, UserID: owner, Visibility: bag.VisibilityPrivate, ViewerIDs: []string{viewer}},
+		publicBag: {ID: publicBag, UserID: owner, Visibility: bag.VisibilityPublic, ViewerIDs: []string{viewer}},
+	}}
+	svc := NewBagService(bags, &fakeDiscRepo{}, nil, nil, nil, nil)
+
+	tests := []struct {
+		caller      string
+		wantViewers bool
+	}{
+		{owner, true},
+		{viewer, false},
+		{"stranger-1", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run("caller "+tt.caller, func(t *testing.T) {
+			ctx := context.Background()
+
+			list, err := svc.GetBagsForUser(ctx, owner, tt.caller)
+			if err != nil {
+				t.Fatalf("GetBagsForUser: %v", err)
+			}
+			if len(list) == 0 {
+				t.Fatal("GetBagsForUser returned no bags")
+			}
+			for _, b := range list {
+				if got := len(b.ViewerIDs) > 0; got != tt.wantViewers {
+					t.Errorf("GetBagsForUser: bag %s ViewerIDs = %v", b.ID.Hex(), b.ViewerIDs)
+				}
+			}
+
+			withDiscs, err := svc.GetBagWithDiscs(ctx, tt.caller, publicBag)
+			if err != nil {
+				t.Fatalf("GetBagWithDiscs: %v", err)
+			}
+			if got := len(withDiscs.Bag.ViewerIDs) > 0; got != tt.wantViewers {
+				t.Errorf("GetBagWithDiscs: ViewerIDs = %v", withDiscs.Bag.ViewerIDs)
+			}
+		})
+	}
+
+	// Hiding the list from one caller must not strip it from the stored bag.
+	if len(bags.bags[sharedBag].ViewerIDs) != 1 {
+		t.Errorf("stored ViewerIDs = %v, want [%s]", bags.bags[sharedBag].ViewerIDs, viewer)
+	}
+}
+
+// fakeTx runs fn once and fails the commit when err is set.
+type fakeTx struct {
+	err error
+}
+
+func (tx *fakeTx) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	return tx.err
+}
+
+type fakeBlobStore struct {
+	storage.BlobStore
+	deleted []string
+}
+
+func (s *fakeBlobStore) Delete(_ context.Context, key string) error {
+	s.deleted = append(s.deleted, key)
+	return nil
+}
+
+// Deleting a bag's discs removes their photos, but only once the transaction
+// has committed, and never the photos of discs that are only detached.
+func TestDeleteBagRemovesPhotosAfterCommit(t *testing.T) {
+	const owner = "owner-1"
+
+	tests := []struct {
+		name      string
+		mode      string
+		commitErr error
+		want      []string
+	}{
+		{"delete", bag.DeleteModeDelete, nil, []string{"in-bag.png", "in-bag_thumb.jpg"}},
+		{"delete, commit fails", bag.DeleteModeDelete, errors.New("commit failed"), nil},
+		{"shelve", bag.DeleteModeShelve, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bagID := bson.NewObjectID()
+			bags := &fakeBagRepo{bags: map[bson.ObjectID]*bag.Bag{
+				bagID: {ID: bagID, UserID: owner},
+			}}
+
+			discs := map[bson.ObjectID]*disc.Disc{}
+			for _, status := range []string{disc.StatusInBag, disc.StatusLost} {
+				id := bson.NewObjectID()
+				name := strings.ReplaceAll(status, "_", "-")
+				discs[id] = &disc.Disc{
+					ID:     id,
+					UserID: owner,
+					BagID:  bagID,
+					Status: status,
+					Photos: []disc.Photo{{Key: name + ".png", ThumbKey: name + "_thumb.jpg"}},
+				}
+			}
+
+			blobs := &fakeBlobStore{}
+			svc := NewBagService(bags, &fakeDiscRepo{discs: discs}, nil, nil, &fakeTx{err: tt.commitErr}, blobs)
+
+			userID := owner
+			_, err := svc.DeleteBag(context.Background(), &userID, bagID, bag.DeleteOptions{Mode: tt.mode})
+			if !errors.Is(err, tt.commitErr) {
+				t.Fatalf("err = %v, want %v", err, tt.commitErr)
+			}
+			if !slices.Equal(blobs.deleted, tt.want) {
+				t.Errorf("deleted blobs = %v, want %v", blobs.deleted, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+	"github.com/Tidwell32/zack/apps/api/internal/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// CatalogService combines the shared DiscIt catalog with each user's
+// custom discs.
+type CatalogService struct {
+	catalogRepo  repository.DiscCatalogRepository
+	customRepo   repository.CustomDiscRepository
+	settingsRepo repository.UserSettingsRepository
+	discRepo     repository.DiscRepository
+	overrideRepo repository.CatalogOverrideRepository
+	plasticRepo  repository.PlasticRepository
+	changeRepo   repository.CatalogChangeRepository
+	index        catalogIndex
+}
+
+func NewCatalogService(
+	catalogRepo repository.DiscCatalogRepository,
+	customRepo repository.CustomDiscRepository,
+	settingsRepo repository.UserSettingsRepository,
+	discRepo repository.DiscRepository,
+	overrideRepo repository.CatalogOverrideRepository,
+	plasticRepo repository.PlasticRepository,
+	changeRepo repository.CatalogChangeRepository,
+) *CatalogService {
+	return &CatalogService{
+		catalogRepo:  catalogRepo,
+		customRepo:   customRepo,
+		settingsRepo: settingsRepo,
+		discRepo:     discRepo,
+		overrideRepo: overrideRepo,
+		plasticRepo:  plasticRepo,
+		changeRepo:   changeRepo,
+	}
+}
+
+// Search is the typo-tolerant quick search: DiscIt discs from the fuzzy
+// index plus the user's custom discs, ranked together by score.
+func (s *CatalogService) Search(ctx context.Context, userID, query string, limit int) ([]catalog.ScoredDisc, error) {
+	var results []catalog.ScoredDisc
+
+	if idx := s.fuzzyIndex(ctx); idx != nil {
+		results = idx.Search(query, limit)
+	} else {
+		discs, err := s.catalogRepo.Search(ctx, query, limit)
+		if err != nil {
+			return nil, err
+		}
+		results = catalog.RankDiscs(query, discs, 0)
+	}
+
+	if userID != "" {
+		custom, err := s.customRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		discs := make([]*catalog.CatalogDisc, len(custom))
+		for i, d := range custom {
+			discs[i] = &d.CatalogDisc
+		}
+		// Custom discs go first so they win ties with DiscIt molds.
+		results = append(catalog.RankDiscs(query, discs, limit), results...)
+	}
+
+	sort.SliceStable(results, func(a, b int) bool {
+		return results[a].Score > results[b].Score
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+// FacetedSearch runs a filtered, ranked and paginated search over the
+// DiscIt catalog and the user's custom discs.
+func (s *CatalogService) FacetedSearch(ctx context.Context, userID string, query repository.CatalogSearchQuery) (*catalog.SearchPage, error) {
+	query.UserID = userID
+	return s.catalogRepo.FacetedSearch(ctx, query)
+}
+
+// Suggest ranks catalog discs for a flight-chart slot using the user's
+// brand preferences and the molds they already own.
+func (s *CatalogService) Suggest(ctx context.Context, userID string, criteria repository.SuggestCriteria) ([]*catalog.CatalogDisc, error) {
+	us, err := loadSettings(ctx, s.settingsRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria.PreferredBrands = us.Suggest.PreferredBrands
+	criteria.ExcludedBrands = us.Suggest.ExcludedBrands
+	criteria.OwnedMoldPenalty = us.Suggest.OwnedMoldPenalty
+
+	if userID != "" && (criteria.ExcludeOwned || criteria.OwnedMoldPenalty > 0) {
+		owned, err := s.discRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make([]string, 0, len(owned))
+		for _, d := range owned {
+			if d.CatalogDiscID != "" {
+				ids = append(ids, d.CatalogDiscID)
+			}
+		}
+
+		if criteria.ExcludeOwned {
+			criteria.ExcludeCatalogIDs = ids
+		} else {
+			criteria.OwnedCatalogIDs = ids
+		}
+	}
+
+	return s.catalogRepo.Suggest(ctx, criteria)
+}
+
+// Similar returns the molds nearest to a catalog disc in flight-number
+// space, drawn from the DiscIt catalog and the user's custom discs.
+func (s *CatalogService) Similar(ctx context.Context, userID, id string, opts catalog.SimilarOptions) ([]catalog.SimilarDisc, error) {
+	ref, err := s.FindForUser(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if ref == nil {
+		return nil, ErrCatalogDiscNotFound
+	}
+
+	var discs []*catalog.CatalogDisc
+	if idx := s.fuzzyIndex(ctx); idx != nil {
+		discs = idx.Discs()
+	} else {
+		discs, err = s.catalogRepo.FindAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if userID != "" {
+		custom, err := s.customRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		candidates := make([]*catalog.CatalogDisc, 0, len(discs)+len(custom))
+		candidates = append(candidates, discs...)
+		for _, d := range custom {
+			candidates = append(candidates, &d.CatalogDisc)
+		}
+		discs = candidates
+	}
+
+	return catalog.RankSimilar(ref, discs, opts), nil
+}
+
+// FindForUser resolves a catalog ID to a DiscIt disc or one of the user's
+// custom discs. Unknown and malformed IDs return nil.
+func (s *CatalogService) FindForUser(ctx context.Context, userID, id string) (*catalog.CatalogDisc, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+
+	d, err := s.catalogRepo.FindByID(ctx, id)
+	if err != nil || d != nil {
+		return d, err
+	}
+
+	if userID == "" {
+		return nil, nil
+	}
+
+	custom, err := s.customRepo.FindByID(ctx, oid, userID)
+	if err != nil || custom == nil {
+		return nil, err
+	}
+	return &custom.CatalogDisc, nil
+}
+
+// FindBySlugForUser looks a mold up by brand and name slug in the DiscIt
+// catalog, then among the user's custom discs.
+func (s *CatalogService) FindBySlugForUser(ctx context.Context, userID, brandSlug, nameSlug string) (*catalog.CatalogDisc, error) {
+	d, err := s.catalogRepo.FindBySlug(ctx, brandSlug, nameSlug)
+	if err != nil || d != nil {
+		return d, err
+	}
+
+	if userID == "" {
+		return nil, nil
+	}
+
+	custom, err := s.customRepo.FindBySlug(ctx, userID, brandSlug, nameSlug)
+	if err != nil || custom == nil {
+		return nil, err
+	}
+	return &custom.CatalogDisc, nil
+}
+
+func (s *CatalogService) GetCustomDiscs(ctx context.Context, userID string) ([]*catalog.CustomDisc, error) {
+	return s.customRepo.FindByUserID(ctx, userID)
+}
+
+func (s *CatalogService) CreateCustomDisc(ctx context.Context, userID string, input catalog.CustomDiscInput) (*catalog.CustomDisc, error) {
+	d := catalog.NewCustomDisc(userID, input, time.Now().UTC())
+	if err := s.ensureCustomSlugFree(ctx, d); err != nil {
+		return nil, err
+	}
+
+	if err := s.customRepo.Create(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// UpdateCustomDisc only changes the catalog entry; discs already in bags
+// keep the flight numbers they were added with.
+func (s *CatalogService) UpdateCustomDisc(
+	ctx context.Context,
+	userID string,
+	id bson.ObjectID,
+	input catalog.CustomDiscInput,
+) (*catalog.CustomDisc, error) {
+	d, err := s.customRepo.FindByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, ErrNotFound
+	}
+
+	d.Apply(input, time.Now().UTC())
+	if err := s.ensureCustomSlugFree(ctx, d); err != nil {
+		return nil, err
+	}
+
+	if err := s.customRepo.Update(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (s *CatalogService) DeleteCustomDisc(ctx context.Context, userID string, id bson.ObjectID) error {
+	deleted, err := s.customRepo.Delete(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ensureCustomSlugFree rejects a custom disc that would shadow a DiscIt
+// mold or another of the user's custom discs.
+func (s *CatalogService) ensureCustomSlugFree(ctx context.Context, d *catalog.CustomDisc) error {
+	existing, err := s.catalogRepo.FindBySlug(ctx, d.BrandSlug, d.NameSlug)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return ErrCatalogDiscExists
+	}
+
+	custom, err := s.customRepo.FindBySlug(ctx, d.OwnerUserID, d.BrandSlug, d.NameSlug)
+	if err != nil {
+		return err
+	}
+	if custom != nil && custom.ID != d.ID {
+		return ErrCatalogDiscExists
+	}
+	return nil
+}
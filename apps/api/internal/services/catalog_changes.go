@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+	"github.com/Tidwell32/zack/apps/api/internal/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// GetCatalogChanges lists recorded catalog changes, newest first.
+func (s *CatalogService) GetCatalogChanges(ctx context.Context, query repository.CatalogChangeQuery) ([]catalog.Change, error) {
+	return s.changeRepo.Find(ctx, query)
+}
+
+// NewReleases lists molds added to the catalog since query.Since, newest
+// first. Without explicit brands it uses the brands the user follows (their
+// stored preferred suggestion brands). Users who follow none, or never saved
+// settings, see every brand; the default suggestion brands are not a follow
+// list.
+func (s *CatalogService) NewReleases(ctx context.Context, userID string, query repository.CatalogChangeQuery) ([]catalog.NewRelease, error) {
+	if len(query.BrandSlugs) == 0 && userID != "" {
+		us, err := s.settingsRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if us != nil {
+			query.BrandSlugs = us.Suggest.PreferredBrands
+		}
+	}
+	query.Kinds = []string{catalog.ChangeAdded}
+
+	changes, err := s.changeRepo.Find(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return []catalog.NewRelease{}, nil
+	}
+
+	ids := make([]bson.ObjectID, len(changes))
+	for i, c := range changes {
+		ids[i] = c.CatalogID
+	}
+	discs, err := s.catalogRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[bson.ObjectID]*catalog.CatalogDisc, len(discs))
+	for _, d := range discs {
+		byID[d.ID] = d
+	}
+
+	releases := make([]catalog.NewRelease, 0, len(changes))
+	for _, c := range changes {
+		// A mold can be recorded twice if the catalog was reset and
+		// re-seeded; the entry no longer resolves and is skipped.
+		d, ok := byID[c.CatalogID]
+		if !ok {
+			continue
+		}
+		releases = append(releases, catalog.NewRelease{CatalogDisc: d, AddedAt: c.At})
+	}
+
+	return releases, nil
+}
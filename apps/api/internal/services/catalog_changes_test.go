@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+	"github.com/Tidwell32/zack/apps/api/internal/repository"
+	"github.com/Tidwell32/zack/apps/api/internal/settings"
+)
+
+type fakeSettingsRepo struct {
+	repository.UserSettingsRepository
+	settings map[string]*settings.UserSettings
+}
+
+func (r *fakeSettingsRepo) FindByUserID(_ context.Context, userID string) (*settings.UserSettings, error) {
+	return r.settings[userID], nil
+}
+
+type fakeChangeRepo struct {
+	query repository.CatalogChangeQuery
+}
+
+func (r *fakeChangeRepo) Find(_ context.Context, query repository.CatalogChangeQuery) ([]catalog.Change, error) {
+	r.query = query
+	return nil, nil
+}
+
+func TestNewReleasesBrands(t *testing.T) {
+	repo := &fakeSettingsRepo{settings: map[string]*settings.UserSettings{
+		"follower": {UserID: "follower", Suggest: settings.SuggestPreferences{PreferredBrands: []string{"mvp"}}},
+		"none":     {UserID: "none", Suggest: settings.SuggestPreferences{PreferredBrands: []string{}}},
+	}}
+
+	tests := []struct {
+		name   string
+		userID string
+		brands []string
+		want   []string
+	}{
+		{"explicit brands", "follower", []string{"innova"}, []string{"innova"}},
+		{"followed brands", "follower", nil, []string{"mvp"}},
+		{"follows none", "none", nil, []string{}},
+		{"no stored settings", "new-user", nil, nil},
+		{"anonymous", "", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := &fakeChangeRepo{}
+			svc := NewCatalogService(nil, nil, repo, nil, nil, nil, changes)
+
+			if _, err := svc.NewReleases(context.Background(), tt.userID, repository.CatalogChangeQuery{BrandSlugs: tt.brands}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(changes.query.BrandSlugs, tt.want) {
+				t.Errorf("BrandSlugs = %v, want %v", changes.query.BrandSlugs, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+)
+
+// CatalogIndexTTL is how long a running API keeps its fuzzy index. The
+// catalog only changes when it is re-seeded, which may happen from another
+// process (cmd/reseed), so the index is rebuilt once it is this old.
+const CatalogIndexTTL = 15 * time.Minute
+
+// catalogIndex holds the current fuzzy index. Searches never wait on a
+// rebuild once an index exists; a stale one is replaced in the background.
+type catalogIndex struct {
+	mu         sync.RWMutex
+	index      *catalog.FuzzyIndex
+	loadedAt   time.Time
+	refreshing bool
+}
+
+// RefreshIndex rebuilds the fuzzy index from the stored catalog. Call it
+// at startup and after seeding.
+func (s *CatalogService) RefreshIndex(ctx context.Context) error {
+	discs, err := s.catalogRepo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	idx := catalog.NewFuzzyIndex(discs)
+
+	s.index.mu.Lock()
+	s.index.index = idx
+	s.index.loadedAt = time.Now()
+	s.index.mu.Unlock()
+
+	log.Printf("📚 Catalog index loaded with %d discs", idx.Len())
+	return nil
+}
+
+// fuzzyIndex returns the current index, loading it on first use. It
+// returns nil when the catalog can't be loaded so callers can fall back to
+// the database.
+func (s *CatalogService) fuzzyIndex(ctx context.Context) *catalog.FuzzyIndex {
+	s.index.mu.RLock()
+	idx, loadedAt := s.index.index, s.index.loadedAt
+	s.index.mu.RUnlock()
+
+	if idx == nil {
+		if err := s.RefreshIndex(ctx); err != nil {
+			log.Printf("warning: failed to load catalog index: %v", err)
+			return nil
+		}
+		s.index.mu.RLock()
+		defer s.index.mu.RUnlock()
+		return s.index.index
+	}
+
+	if time.Since(loadedAt) > CatalogIndexTTL {
+		s.refreshIndexInBackground()
+	}
+	return idx
+}
+
+func (s *CatalogService) refreshIndexInBackground() {
+	s.index.mu.Lock()
+	if s.index.refreshing {
+		s.index.mu.Unlock()
+		return
+	}
+	s.index.refreshing = true
+	s.index.mu.Unlock()
+
+	go func() {
+		defer func() {
+			s.index.mu.Lock()
+			s.index.refreshing = false
+			s.index.mu.Unlock()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := s.RefreshIndex(ctx); err != nil {
+			log.Printf("warning: failed to refresh catalog index: %v", err)
+		}
+	}()
+}
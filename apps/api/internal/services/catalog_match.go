@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Tidwell32/zack/apps/api/internal/bag"
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+)
+
+// Fuzzy matches scoring below this are reported as unmatched rather than
+// guessed at.
+const minFuzzyScore = 0.8
+
+// How many trigram candidates are scored by edit distance per lookup.
+const matchCandidateLimit = 50
+
+type catalogMatch struct {
+	disc      *catalog.CatalogDisc
+	matchType string
+	score     float64
+}
+
+// matchDisc finds the catalog entry for a brand and mold typed by a person
+// or another tool. Slugs are tried first; otherwise candidates that share
+// trigrams with the name are scored by edit distance. Without an index it
+// falls back to a substring search on the name's first letters.
+func (s *CatalogService) matchDisc(ctx context.Context, brand, name string) (*catalogMatch, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, nil
+	}
+
+	idx := s.fuzzyIndex(ctx)
+
+	if brand != "" {
+		var exact *catalog.CatalogDisc
+		if idx != nil {
+			exact = idx.FindBySlug(catalog.Slugify(brand), catalog.Slugify(name))
+		} else {
+			var err error
+			exact, err = s.catalogRepo.FindBySlug(ctx, catalog.Slugify(brand), catalog.Slugify(name))
+			if err != nil {
+				return nil, err
+			}
+		}
+		if exact != nil {
+			return &catalogMatch{disc: exact, matchType: bag.MatchExact, score: 1}, nil
+		}
+	}
+
+	var candidates []*catalog.CatalogDisc
+	if idx != nil {
+		candidates = idx.Candidates(name, matchCandidateLimit)
+	} else {
+		prefix := []rune(strings.TrimSpace(name))
+		if len(prefix) > 3 {
+			prefix = prefix[:3]
+		}
+
+		var err error
+		candidates, err = s.catalogRepo.Search(ctx, string(prefix), 200)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var best *catalogMatch
+	for _, c := range candidates {
+		score := catalog.Similarity(name, c.Name)
+		if brand != "" {
+			score = 0.75*score + 0.25*catalog.Similarity(brand, c.Brand)
+		}
+		if best == nil || score > best.score {
+			best = &catalogMatch{disc: c, matchType: bag.MatchFuzzy, score: score}
+		}
+	}
+
+	if best == nil || best.score < minFuzzyScore {
+		return nil, nil
+	}
+
+	return best, nil
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// OverrideCatalogDisc records a correction to a DiscIt disc and applies it
+// to the stored catalog. The override is saved first: if applying it fails,
+// the next catalog sync puts it in place.
+func (s *CatalogService) OverrideCatalogDisc(ctx context.Context, userID string, id bson.ObjectID, patch catalog.OverrideFields) (*catalog.CatalogDisc, error) {
+	d, err := s.catalogRepo.FindByID(ctx, id.Hex())
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, ErrCatalogDiscNotFound
+	}
+
+	now := time.Now().UTC()
+
+	override, err := s.overrideRepo.FindByCatalogID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if override == nil {
+		override = &catalog.Override{
+			ID:        bson.NewObjectID(),
+			CatalogID: id,
+			Audit:     []catalog.OverrideAudit{},
+			CreatedAt: now,
+		}
+	}
+
+	changes := override.Merge(patch, d)
+	if len(changes) == 0 {
+		return d, nil
+	}
+
+	override.Audit = append(override.Audit, catalog.OverrideAudit{
+		UserID:  userID,
+		At:      now,
+		Changes: changes,
+	})
+	override.UpdatedAt = now
+
+	if err := s.overrideRepo.Save(ctx, override); err != nil {
+		return nil, err
+	}
+	if err := s.catalogRepo.ApplyOverride(ctx, id, override.Fields); err != nil {
+		return nil, err
+	}
+
+	// Searches and import matching read the in-memory index.
+	if err := s.RefreshIndex(ctx); err != nil {
+		log.Printf("warning: failed to refresh catalog index after override: %v", err)
+	}
+
+	override.Fields.Apply(d)
+	return d, nil
+}
+
+// ClearCatalogOverride drops the named overrides (all of them when names is
+// empty) and puts the DiscIt values back on the stored catalog. The clear is
+// added to the audit trail; the override document stays so the trail does.
+func (s *CatalogService) ClearCatalogOverride(ctx context.Context, userID string, id bson.ObjectID, names []string) (*catalog.CatalogDisc, error) {
+	d, err := s.catalogRepo.FindByID(ctx, id.Hex())
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, ErrCatalogDiscNotFound
+	}
+
+	override, err := s.overrideRepo.FindByCatalogID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if override == nil {
+		return nil, ErrNotFound
+	}
+
+	restored, changes := override.Clear(names, d)
+	if len(changes) == 0 {
+		return d, nil
+	}
+
+	now := time.Now().UTC()
+	override.Audit = append(override.Audit, catalog.OverrideAudit{
+		UserID:  userID,
+		At:      now,
+		Changes: changes,
+	})
+	override.UpdatedAt = now
+
+	if err := s.overrideRepo.Save(ctx, override); err != nil {
+		return nil, err
+	}
+	if err := s.catalogRepo.ApplyOverride(ctx, id, restored); err != nil {
+		return nil, err
+	}
+
+	if err := s.RefreshIndex(ctx); err != nil {
+		log.Printf("warning: failed to refresh catalog index after clearing override: %v", err)
+	}
+
+	restored.Apply(d)
+	return d, nil
+}
+
+// GetCatalogOverride returns a disc's corrections and their audit trail.
+func (s *CatalogService) GetCatalogOverride(ctx context.Context, id bson.ObjectID) (*catalog.Override, error) {
+	override, err := s.overrideRepo.FindByCatalogID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if override == nil {
+		return nil, ErrNotFound
+	}
+	return override, nil
+}
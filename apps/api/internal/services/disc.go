@@ -1,32 +1,68 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"strings"
 	"time"
 
+	"github.com/Tidwell32/zack/apps/api/internal/bag"
 	"github.com/Tidwell32/zack/apps/api/internal/catalog"
 	"github.com/Tidwell32/zack/apps/api/internal/disc"
 	"github.com/Tidwell32/zack/apps/api/internal/repository"
+	"github.com/Tidwell32/zack/apps/api/internal/storage"
+	"github.com/Tidwell32/zack/apps/api/internal/techdisc"
+	"github.com/Tidwell32/zack/apps/api/internal/udisc"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
+// CatalogDiscProvider resolves catalog IDs, including the user's own
+// custom discs.
 type CatalogDiscProvider interface {
-	FindByID(ctx context.Context, id string) (*catalog.CatalogDisc, error)
+	FindForUser(ctx context.Context, userID, id string) (*catalog.CatalogDisc, error)
+	FindBySlugForUser(ctx context.Context, userID, brandSlug, nameSlug string) (*catalog.CatalogDisc, error)
+	ResolvePlastic(ctx context.Context, brandSlug, name string) (string, error)
+}
+
+// RoundProvider is the slice of UDisc data discs care about: courses for
+// lost-disc links and round times for seasoning.
+type RoundProvider interface {
+	GetDistinctCourses(ctx context.Context, userID string) ([]udisc.CourseInfo, error)
+	GetRoundsForUser(ctx context.Context, userID string) ([]udisc.Round, error)
+}
+
+type ThrowProvider interface {
+	GetThrowsForUser(ctx context.Context, userID string) ([]techdisc.ThrowRaw, error)
 }
 
 type DiscService struct {
 	discRepo   repository.DiscRepository
+	bagRepo    repository.BagRepository
 	catalogSvc CatalogDiscProvider
+	roundSvc   RoundProvider
+	throwSvc   ThrowProvider
+	blobs      storage.BlobStore
 }
 
 func NewDiscService(
 	discRepo repository.DiscRepository,
+	bagRepo repository.BagRepository,
 	catalogSvc CatalogDiscProvider,
+	roundSvc RoundProvider,
+	throwSvc ThrowProvider,
+	blobs storage.BlobStore,
 ) *DiscService {
 	return &DiscService{
 		discRepo:   discRepo,
+		bagRepo:    bagRepo,
 		catalogSvc: catalogSvc,
+		roundSvc:   roundSvc,
+		throwSvc:   throwSvc,
+		blobs:      blobs,
 	}
 }
 
@@ -36,16 +72,50 @@ func (s *DiscService) AddDiscToBag(
 	bagID bson.ObjectID,
 	input disc.CreateDiscInput,
 ) (*disc.Disc, bool, error) {
-	catalogDisc, err := s.catalogSvc.FindByID(ctx, input.CatalogDiscID)
+	if userID != nil {
+		if _, err := loadBagWithAccess(ctx, s.bagRepo, bagID, *userID, bag.AccessOwner); err != nil {
+			return nil, false, err
+		}
+	}
+
+	var ownerID string
+	if userID != nil {
+		ownerID = *userID
+	}
+
+	catalogDisc, err := s.catalogSvc.FindForUser(ctx, ownerID, input.CatalogDiscID)
 	if err != nil {
 		return nil, false, fmt.Errorf("failed to load catalog disc: %w", err)
 	}
 	if catalogDisc == nil {
-		return nil, false, fmt.Errorf("catalog disc not found")
+		return nil, false, ErrCatalogDiscNotFound
 	}
 
-	now := time.Now().UTC()
+	input.Plastic, err = s.catalogSvc.ResolvePlastic(ctx, catalogDisc.BrandSlug, input.Plastic)
+	if err != nil {
+		return nil, false, err
+	}
+
+	d := newDiscFromCatalog(catalogDisc, bagID, input, time.Now().UTC())
+
+	if userID != nil {
+		d.UserID = *userID
+		if err := s.discRepo.Create(ctx, d); err != nil {
+			return nil, false, fmt.Errorf("failed to create disc: %w", err)
+		}
+		return d, true, nil
+	}
+
+	return d, false, nil
+}
 
+// newDiscFromCatalog copies a catalog disc into a new in-bag disc.
+func newDiscFromCatalog(
+	catalogDisc *catalog.CatalogDisc,
+	bagID bson.ObjectID,
+	input disc.CreateDiscInput,
+	now time.Time,
+) *disc.Disc {
 	stockFlight := disc.FlightNumbers{
 		Speed: catalogDisc.Speed,
 		Glide: catalogDisc.Glide,
@@ -56,7 +126,7 @@ func (s *DiscService) AddDiscToBag(
 	d := &disc.Disc{
 		ID:            bson.NewObjectID(),
 		BagID:         bagID,
-		CatalogDiscID: input.CatalogDiscID,
+		CatalogDiscID: catalogDisc.ID.Hex(),
 
 		Name:         catalogDisc.Name,
 		Brand:        catalogDisc.Brand,
@@ -72,27 +142,30 @@ func (s *DiscService) AddDiscToBag(
 		Notes:          input.Notes,
 		AdjustedFlight: input.AdjustedFlight,
 
+		Status: disc.StatusInBag,
+		StatusHistory: []disc.StatusChange{
+			{Status: disc.StatusInBag, ChangedAt: now},
+		},
+
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 
-	if userID != nil {
-		d.UserID = *userID
-		if err := s.discRepo.Create(ctx, d); err != nil {
-			return nil, false, fmt.Errorf("failed to create disc: %w", err)
+	if input.AdjustedFlight != nil {
+		d.FlightHistory = []disc.FlightChange{
+			{Flight: input.AdjustedFlight, ChangedAt: now, Reason: "initial"},
 		}
-		return d, true, nil
 	}
 
-	return d, false, nil
+	return d
 }
 
-func (s *DiscService) GetDiscsForBag(ctx context.Context, bagID bson.ObjectID) ([]*disc.Disc, error) {
-	return s.discRepo.FindByBagID(ctx, bagID)
-}
+func (s *DiscService) GetDiscsForBag(ctx context.Context, callerID string, bagID bson.ObjectID) ([]*disc.Disc, error) {
+	if _, err := loadBagWithAccess(ctx, s.bagRepo, bagID, callerID, bag.AccessViewer); err != nil {
+		return nil, err
+	}
 
-func (s *DiscService) GetDiscByID(ctx context.Context, id bson.ObjectID) (*disc.Disc, error) {
-	return s.discRepo.FindByID(ctx, id)
+	return s.discRepo.FindByBagID(ctx, bagID)
 }
 
 func (s *DiscService) GetDiscsForUser(ctx context.Context, userID string) ([]*disc.Disc, error) {
@@ -123,22 +196,39 @@ func (s *DiscService) UpdateDisc(
 		return d, false, nil
 	}
 
-	existing, err := s.discRepo.FindByID(ctx, discID)
+	existing, err := s.loadOwnedDisc(ctx, *userID, discID)
 	if err != nil {
 		return nil, false, err
 	}
-	if existing == nil {
-		return nil, false, ErrNotFound
-	}
 
-	if input.BagID != nil {
+	if input.BagID != nil && *input.BagID != existing.BagID {
+		if _, err := loadBagWithAccess(ctx, s.bagRepo, *input.BagID, *userID, bag.AccessOwner); err != nil {
+			return nil, false, err
+		}
 		existing.BagID = *input.BagID
 	}
-	existing.Plastic = input.Plastic
+	// Only a changed plastic is checked, so discs saved before the registry
+	// existed stay editable.
+	if input.Plastic != existing.Plastic {
+		plastic, err := s.catalogSvc.ResolvePlastic(ctx, existing.BrandSlug, input.Plastic)
+		if err != nil {
+			return nil, false, err
+		}
+		existing.Plastic = plastic
+	}
 	existing.Weight = input.Weight
 	existing.ColorHex = input.ColorHex
 	existing.Notes = input.Notes
-	existing.AdjustedFlight = input.AdjustedFlight
+	if input.AdjustedFlight != nil || input.ResetFlight {
+		if !sameFlight(existing.AdjustedFlight, input.AdjustedFlight) {
+			existing.FlightHistory = append(existing.FlightHistory, disc.FlightChange{
+				Flight:    input.AdjustedFlight,
+				ChangedAt: now,
+				Reason:    input.FlightReason,
+			})
+		}
+		existing.AdjustedFlight = input.AdjustedFlight
+	}
 	existing.UpdatedAt = now
 
 	if err := s.discRepo.Update(ctx, existing); err != nil {
@@ -149,12 +239,460 @@ func (s *DiscService) UpdateDisc(
 }
 
 func (s *DiscService) DeleteDisc(ctx context.Context, userID *string, id bson.ObjectID) (bool, error) {
-	if userID != nil {
-		if err := s.discRepo.Delete(ctx, id); err != nil {
-			return false, err
+	if userID == nil {
+		return false, nil
+	}
+
+	d, err := s.loadOwnedDisc(ctx, *userID, id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.discRepo.Delete(ctx, id); err != nil {
+		return false, err
+	}
+
+	for _, p := range d.Photos {
+		deletePhotoBlobs(ctx, s.blobs, p)
+	}
+	return true, nil
+}
+
+// AddPhoto stores an uploaded image and its thumbnail and attaches them to
+// the disc.
+func (s *DiscService) AddPhoto(ctx context.Context, userID string, discID bson.ObjectID, data []byte) (*disc.Photo, error) {
+	d, err := s.loadOwnedDisc(ctx, userID, discID)
+	if err != nil {
+		return nil, err
+	}
+	if len(d.Photos) >= disc.MaxPhotos {
+		return nil, ErrTooManyPhotos
+	}
+
+	processed, err := disc.ProcessPhoto(data)
+	if err != nil {
+		return nil, err
+	}
+
+	photoID := bson.NewObjectID()
+	prefix := fmt.Sprintf("discs/%s/%s", discID.Hex(), photoID.Hex())
+	photo := disc.Photo{
+		ID:          photoID,
+		Key:         prefix + "." + processed.Ext,
+		ThumbKey:    prefix + "_thumb.jpg",
+		ContentType: processed.ContentType,
+		Width:       processed.Width,
+		Height:      processed.Height,
+		SizeBytes:   int64(len(data)),
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if err := s.blobs.Put(ctx, photo.Key, bytes.NewReader(data), int64(len(data)), photo.ContentType); err != nil {
+		return nil, fmt.Errorf("failed to store photo: %w", err)
+	}
+	if err := s.blobs.Put(ctx, photo.ThumbKey, bytes.NewReader(processed.Thumbnail), int64(len(processed.Thumbnail)), "image/jpeg"); err != nil {
+		deletePhotoBlobs(ctx, s.blobs, photo)
+		return nil, fmt.Errorf("failed to store thumbnail: %w", err)
+	}
+
+	if err := s.discRepo.AddPhoto(ctx, discID, photo); err != nil {
+		deletePhotoBlobs(ctx, s.blobs, photo)
+		return nil, err
+	}
+
+	return &photo, nil
+}
+
+// GetPhoto opens a photo (or its thumbnail) for anyone who can see the
+// disc's bag. The caller must close the reader.
+func (s *DiscService) GetPhoto(
+	ctx context.Context,
+	callerID string,
+	discID, photoID bson.ObjectID,
+	thumbnail bool,
+) (io.ReadCloser, string, error) {
+	d, err := s.discRepo.FindByID(ctx, discID)
+	if err != nil {
+		return nil, "", err
+	}
+	if d == nil {
+		return nil, "", ErrNotFound
+	}
+
+	if d.UserID != callerID {
+		if _, err := loadBagWithAccess(ctx, s.bagRepo, d.BagID, callerID, bag.AccessViewer); err != nil {
+			return nil, "", err
+		}
+	}
+
+	photo := findPhoto(d, photoID)
+	if photo == nil {
+		return nil, "", ErrNotFound
+	}
+
+	key := photo.Key
+	if thumbnail {
+		key = photo.ThumbKey
+	}
+
+	body, contentType, err := s.blobs.Get(ctx, key)
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		return nil, "", ErrNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if contentType == "" {
+		contentType = photo.ContentType
+	}
+	return body, contentType, nil
+}
+
+func (s *DiscService) DeletePhoto(ctx context.Context, userID string, discID, photoID bson.ObjectID) error {
+	d, err := s.loadOwnedDisc(ctx, userID, discID)
+	if err != nil {
+		return err
+	}
+
+	photo := findPhoto(d, photoID)
+	if photo == nil {
+		return ErrNotFound
+	}
+
+	if err := s.discRepo.RemovePhoto(ctx, discID, photoID); err != nil {
+		return err
+	}
+
+	deletePhotoBlobs(ctx, s.blobs, *photo)
+	return nil
+}
+
+// deletePhotoBlobs is best effort: the disc document is the source of
+// truth, and a leftover blob only costs storage.
+func deletePhotoBlobs(ctx context.Context, blobs storage.BlobStore, photo disc.Photo) {
+	for _, key := range []string{photo.Key, photo.ThumbKey} {
+		if err := blobs.Delete(ctx, key); err != nil {
+			log.Printf("warning: failed to delete photo blob %s: %v", key, err)
 		}
-		return true, nil
 	}
+}
 
-	return false, nil
+func findPhoto(d *disc.Disc, photoID bson.ObjectID) *disc.Photo {
+	for i := range d.Photos {
+		if d.Photos[i].ID == photoID {
+			return &d.Photos[i]
+		}
+	}
+	return nil
+}
+
+// UpdateDiscStatus moves a disc between bag, shelf, lost and gone. Every
+// change is appended to the disc's status history.
+func (s *DiscService) UpdateDiscStatus(
+	ctx context.Context,
+	userID string,
+	discID bson.ObjectID,
+	input disc.UpdateStatusInput,
+) (*disc.Disc, error) {
+	d, err := s.loadOwnedDisc(ctx, userID, discID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-reporting a lost disc is allowed so the course can be corrected.
+	if input.Status == d.Status && input.Status != disc.StatusLost {
+		return nil, ErrStatusUnchanged
+	}
+
+	now := time.Now().UTC()
+	wasLost := d.Status == disc.StatusLost
+	change := disc.StatusChange{
+		Status:    input.Status,
+		ChangedAt: now,
+		Note:      input.Note,
+	}
+
+	if input.RecoveredAt != nil && (!wasLost || input.Status == disc.StatusLost) {
+		return nil, ErrDiscNotLost
+	}
+
+	switch input.Status {
+	case disc.StatusInBag:
+		bagID := d.BagID
+		if input.BagID != nil {
+			bagID = *input.BagID
+		}
+		if bagID.IsZero() {
+			return nil, ErrBagRequired
+		}
+		if _, err := loadBagWithAccess(ctx, s.bagRepo, bagID, userID, bag.AccessOwner); err != nil {
+			return nil, err
+		}
+		d.BagID = bagID
+
+	case disc.StatusLost:
+		courseName := ""
+		if input.CourseName != "" {
+			courseName, err = s.resolveCourseName(ctx, userID, input.CourseName)
+			if err != nil {
+				return nil, err
+			}
+		}
+		d.LostCourse = courseName
+		d.LostAt = &now
+		d.RecoveredAt = nil
+		change.CourseName = courseName
+	}
+
+	if wasLost && input.Status != disc.StatusLost {
+		recoveredAt := now
+		if input.RecoveredAt != nil {
+			recoveredAt = input.RecoveredAt.UTC()
+		}
+		if d.LostAt != nil && recoveredAt.Before(*d.LostAt) {
+			return nil, ErrInvalidRecoveryDate
+		}
+		d.RecoveredAt = &recoveredAt
+	}
+
+	d.Status = input.Status
+	d.StatusHistory = append(d.StatusHistory, change)
+	d.UpdatedAt = now
+
+	if err := s.discRepo.Update(ctx, d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// GetShelf is the full inventory for ownerID across every status. Anyone
+// else only sees the discs sitting in bags they can view; off-bag discs
+// (backups, lost, retired, sold) stay private to the owner.
+func (s *DiscService) GetShelf(ctx context.Context, ownerID, callerID string) (*disc.Shelf, error) {
+	discs, err := s.discRepo.FindByUserID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if callerID != ownerID {
+		bags, err := s.bagRepo.FindByUserID(ctx, ownerID)
+		if err != nil {
+			return nil, err
+		}
+
+		viewable := make(map[bson.ObjectID]bool, len(bags))
+		for _, b := range bags {
+			if b.AccessFor(callerID) != bag.AccessNone {
+				viewable[b.ID] = true
+			}
+		}
+
+		visible := make([]*disc.Disc, 0, len(discs))
+		for _, d := range discs {
+			inBag := d.Status == disc.StatusInBag || d.Status == ""
+			if inBag && viewable[d.BagID] {
+				visible = append(visible, d)
+			}
+		}
+		discs = visible
+	}
+
+	return disc.BuildShelf(discs), nil
+}
+
+// GetDiscHistory returns how the disc's flight numbers drifted from stock,
+// its status timeline and a seasoning estimate.
+func (s *DiscService) GetDiscHistory(ctx context.Context, callerID string, discID bson.ObjectID) (*disc.History, error) {
+	d, err := s.discRepo.FindByID(ctx, discID)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, ErrNotFound
+	}
+
+	if d.UserID != callerID {
+		if _, err := loadBagWithAccess(ctx, s.bagRepo, d.BagID, callerID, bag.AccessViewer); err != nil {
+			return nil, err
+		}
+	}
+
+	rounds, err := s.roundSvc.GetRoundsForUser(ctx, d.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rounds: %w", err)
+	}
+
+	roundTimes := make([]time.Time, 0, len(rounds))
+	for _, r := range rounds {
+		for _, p := range r.Players {
+			if p.IsComplete {
+				roundTimes = append(roundTimes, r.StartTime)
+				break
+			}
+		}
+	}
+
+	throws, err := s.throwSvc.GetThrowsForUser(ctx, d.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load throws: %w", err)
+	}
+
+	taggedThrows := 0
+	for _, t := range throws {
+		if d.IsTaggedWith(t.Tags) {
+			taggedThrows++
+		}
+	}
+
+	roundsInBag := disc.CountInBag(d, roundTimes, time.Now().UTC())
+
+	return disc.NewHistory(d, roundsInBag, taggedThrows), nil
+}
+
+func sameFlight(a, b *disc.FlightNumbers) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (s *DiscService) resolveCourseName(ctx context.Context, userID, courseName string) (string, error) {
+	courses, err := s.roundSvc.GetDistinctCourses(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	for _, c := range courses {
+		if strings.EqualFold(strings.TrimSpace(c.CourseName), courseName) {
+			return c.CourseName, nil
+		}
+	}
+
+	return "", ErrUnknownCourse
+}
+
+// RelinkCatalogDiscs checks every disc's CatalogDiscID against the catalog.
+// Discs whose entry is gone are re-linked by brand and name slug; ones that
+// can't be matched are reported as unresolved. Stock numbers that drifted
+// from the catalog are reported, and refreshed with opts.RefreshStock.
+func (s *DiscService) RelinkCatalogDiscs(ctx context.Context, opts disc.RelinkOptions) (*disc.RelinkReport, error) {
+	var discs []*disc.Disc
+	var err error
+	if opts.UserID != "" {
+		discs, err = s.discRepo.FindByUserID(ctx, opts.UserID)
+	} else {
+		discs, err = s.discRepo.FindAll(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report := &disc.RelinkReport{DryRun: opts.DryRun, Entries: []disc.RelinkEntry{}}
+
+	// Custom discs are per user, so cache lookups per user too.
+	byID := make(map[string]*catalog.CatalogDisc)
+	bySlug := make(map[string]*catalog.CatalogDisc)
+
+	for _, d := range discs {
+		report.Checked++
+
+		idKey := d.UserID + "|" + d.CatalogDiscID
+		entry, ok := byID[idKey]
+		if !ok {
+			entry, err = s.catalogSvc.FindForUser(ctx, d.UserID, d.CatalogDiscID)
+			if err != nil {
+				return nil, err
+			}
+			byID[idKey] = entry
+		}
+
+		relinked := false
+		if entry == nil {
+			slugKey := d.UserID + "|" + d.BrandSlug + "/" + d.NameSlug
+			entry, ok = bySlug[slugKey]
+			if !ok {
+				entry, err = s.catalogSvc.FindBySlugForUser(ctx, d.UserID, d.BrandSlug, d.NameSlug)
+				if err != nil {
+					return nil, err
+				}
+				bySlug[slugKey] = entry
+			}
+			relinked = entry != nil
+		}
+
+		result := disc.RelinkEntry{
+			DiscID:           d.ID,
+			UserID:           d.UserID,
+			Name:             d.Name,
+			Brand:            d.Brand,
+			OldCatalogDiscID: d.CatalogDiscID,
+		}
+
+		if entry == nil {
+			result.Outcome = disc.LinkUnresolved
+			report.Unresolved++
+			report.Entries = append(report.Entries, result)
+			continue
+		}
+
+		stock := disc.FlightNumbers{Speed: entry.Speed, Glide: entry.Glide, Turn: entry.Turn, Fade: entry.Fade}
+		stale := stock != d.StockFlight
+		if stale {
+			before := d.StockFlight
+			result.StockBefore, result.StockAfter = &before, &stock
+			report.StaleStock++
+		}
+
+		if !relinked && !stale {
+			report.Linked++
+			continue
+		}
+
+		result.Outcome = disc.LinkStaleStock
+		if relinked {
+			result.Outcome = disc.LinkRelinked
+			result.NewCatalogDiscID = entry.ID.Hex()
+			report.Relinked++
+		} else {
+			report.Linked++
+		}
+		report.Entries = append(report.Entries, result)
+
+		refresh := stale && opts.RefreshStock
+		if refresh {
+			report.StockRefreshed++
+		}
+		if opts.DryRun || (!relinked && !refresh) {
+			continue
+		}
+
+		catalogDiscID := d.CatalogDiscID
+		if relinked {
+			catalogDiscID = entry.ID.Hex()
+		}
+		newStock := d.StockFlight
+		if refresh {
+			newStock = stock
+		}
+		if err := s.discRepo.SetCatalogLink(ctx, d.ID, catalogDiscID, newStock); err != nil {
+			return nil, fmt.Errorf("failed to relink disc %s: %w", d.ID.Hex(), err)
+		}
+	}
+
+	return report, nil
+}
+
+func (s *DiscService) loadOwnedDisc(ctx context.Context, userID string, id bson.ObjectID) (*disc.Disc, error) {
+	d, err := s.discRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, ErrNotFound
+	}
+	if d.UserID != userID {
+		return nil, ErrForbidden
+	}
+	return d, nil
 }
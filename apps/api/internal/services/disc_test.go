@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Tidwell32/zack/apps/api/internal/bag"
+	"github.com/Tidwell32/zack/apps/api/internal/disc"
+	"github.com/Tidwell32/zack/apps/api/internal/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Only the methods the tests reach are implemented; anything else panics
+// through the nil embedded interface.
+type fakeBagRepo struct {
+	repository.BagRepository
+	bags map[bson.ObjectID]*bag.Bag
+}
+
+func (r *fakeBagRepo) FindByID(_ context.Context, id bson.ObjectID) (*bag.Bag, error) {
+	return r.bags[id], nil
+}
+
+func (r *fakeBagRepo) FindByUserID(_ context.Context, userID string) ([]*bag.Bag, error) {
+	var bags []*bag.Bag
+	for _, b := range r.bags {
+		if b.UserID == userID {
+			bags = append(bags, b)
+		}
+	}
+	return bags, nil
+}
+
+func (r *fakeBagRepo) Delete(_ context.Context, id bson.ObjectID) error {
+	delete(r.bags, id)
+	return nil
+}
+
+type fakeDiscRepo struct {
+	repository.DiscRepository
+	discs   map[bson.ObjectID]*disc.Disc
+	updated []*disc.Disc
+}
+
+func (r *fakeDiscRepo) FindByID(_ context.Context, id bson.ObjectID) (*disc.Disc, error) {
+	d, ok := r.discs[id]
+	if !ok {
+		return nil, nil
+	}
+	cp := *d
+	return &cp, nil
+}
+
+func (r *fakeDiscRepo) FindByUserID(_ context.Context, userID string) ([]*disc.Disc, error) {
+	var discs []*disc.Disc
+	for _, d := range r.discs {
+		if d.UserID == userID {
+			discs = append(discs, d)
+		}
+	}
+	return discs, nil
+}
+
+func (r *fakeDiscRepo) FindByBagID(_ context.Context, bagID bson.ObjectID) ([]*disc.Disc, error) {
+	var discs []*disc.Disc
+	for _, d := range r.discs {
+		if d.BagID == bagID {
+			discs = append(discs, d)
+		}
+	}
+	return discs, nil
+}
+
+func (r *fakeDiscRepo) DeleteInBag(_ context.Context, bagID bson.ObjectID) (int64, error) {
+	var n int64
+	for id, d := range r.discs {
+		if d.BagID == bagID && d.Status == disc.StatusInBag {
+			delete(r.discs, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeDiscRepo) ShelveBag(_ context.Context, bagID bson.ObjectID, change disc.StatusChange) (int64, error) {
+	var n int64
+	for _, d := range r.discs {
+		if d.BagID == bagID && d.Status == disc.StatusInBag {
+			d.Status = change.Status
+			d.BagID = bson.ObjectID{}
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeDiscRepo) DetachBag(_ context.Context, bagID bson.ObjectID) (int64, error) {
+	var n int64
+	for _, d := range r.discs {
+		if d.BagID == bagID {
+			d.BagID = bson.ObjectID{}
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeDiscRepo) Update(_ context.Context, d *disc.Disc) error {
+	r.updated = append(r.updated, d)
+	return nil
+}
+
+func TestUpdateDiscMoveAccess(t *testing.T) {
+	const (
+		caller = "owner-1"
+		other  = "other-1"
+	)
+
+	ownBag := bson.NewObjectID()
+	ownOtherBag := bson.NewObjectID()
+	publicBag := bson.NewObjectID()
+	privateBag := bson.NewObjectID()
+	viewerBag := bson.NewObjectID()
+	missingBag := bson.NewObjectID()
+
+	bags := &fakeBagRepo{bags: map[bson.ObjectID]*bag.Bag{
+		ownBag:      {ID: ownBag, UserID: caller, Visibility: bag.VisibilityPrivate},
+		ownOtherBag: {ID: ownOtherBag, UserID: caller, Visibility: bag.VisibilityPublic},
+		publicBag:   {ID: publicBag, UserID: other, Visibility: bag.VisibilityPublic},
+		privateBag:  {ID: privateBag, UserID: other, Visibility: bag.VisibilityPrivate},
+		viewerBag:   {ID: viewerBag, UserID: other, Visibility: bag.VisibilityPrivate, ViewerIDs: []string{caller}},
+	}}
+
+	ownDisc := bson.NewObjectID()
+	otherDisc := bson.NewObjectID()
+	discs := map[bson.ObjectID]*disc.Disc{
+		ownDisc:   {ID: ownDisc, UserID: caller, BagID: ownBag, Status: disc.StatusInBag},
+		otherDisc: {ID: otherDisc, UserID: other, BagID: publicBag, Status: disc.StatusInBag},
+	}
+
+	tests := []struct {
+		name    string
+		discID  bson.ObjectID
+		target  bson.ObjectID
+		wantErr error
+	}{
+		{"own bag", ownDisc, ownOtherBag, nil},
+		{"someone else's public bag", ownDisc, publicBag, ErrForbidden},
+		{"someone else's private bag", ownDisc, privateBag, ErrNotFound},
+		{"bag shared with the caller", ownDisc, viewerBag, ErrForbidden},
+		{"missing bag", ownDisc, missingBag, ErrNotFound},
+		{"someone else's disc", otherDisc, ownOtherBag, ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discRepo := &fakeDiscRepo{discs: discs}
+			svc := NewDiscService(discRepo, bags, nil, nil, nil, nil)
+
+			userID := caller
+			target := tt.target
+			d, persisted, err := svc.UpdateDisc(context.Background(), &userID, tt.discID, disc.UpdateDiscInput{BagID: &target})
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				if len(discRepo.updated) != 0 {
+					t.Errorf("disc was saved after a rejected move")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !persisted || len(discRepo.updated) != 1 {
+				t.Fatalf("disc was not saved")
+			}
+			if d.BagID != target {
+				t.Errorf("BagID = %s, want %s", d.BagID.Hex(), target.Hex())
+			}
+		})
+	}
+}
+
+func TestUpdateDiscFlightHistory(t *testing.T) {
+	const caller = "owner-1"
+
+	bagID := bson.NewObjectID()
+	discID := bson.NewObjectID()
+	adjusted := &disc.FlightNumbers{Speed: 12, Glide: 5, Turn: -2, Fade: 2}
+	changed := &disc.FlightNumbers{Speed: 12, Glide: 5, Turn: -3, Fade: 2}
+
+	tests := []struct {
+		name        string
+		input       disc.UpdateDiscInput
+		wantFlight  *disc.FlightNumbers
+		wantHistory int
+	}{
+		{"flight omitted", disc.UpdateDiscInput{Notes: "beat in"}, adjusted, 0},
+		{"same flight", disc.UpdateDiscInput{AdjustedFlight: adjusted}, adjusted, 0},
+		{"new flight", disc.UpdateDiscInput{AdjustedFlight: changed}, changed, 1},
+		{"reset", disc.UpdateDiscInput{ResetFlight: true}, nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discRepo := &fakeDiscRepo{discs: map[bson.ObjectID]*disc.Disc{
+				discID: {ID: discID, UserID: caller, BagID: bagID, AdjustedFlight: adjusted},
+			}}
+			svc := NewDiscService(discRepo, &fakeBagRepo{}, nil, nil, nil, nil)
+
+			userID := caller
+			d, _, err := svc.UpdateDisc(context.Background(), &userID, discID, tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !sameFlight(d.AdjustedFlight, tt.wantFlight) {
+				t.Errorf("AdjustedFlight = %v, want %v", d.AdjustedFlight, tt.wantFlight)
+			}
+			if len(d.FlightHistory) != tt.wantHistory {
+				t.Errorf("len(FlightHistory) = %d, want %d", len(d.FlightHistory), tt.wantHistory)
+			}
+		})
+	}
+}
+
+// Only the owner sees their whole shelf; anyone else sees just the discs in
+// bags they can view, never backups, lost, retired or sold discs.
+func TestGetShelfVisibility(t *testing.T) {
+	const (
+		owner  = "owner-1"
+		viewer = "viewer-1"
+	)
+
+	publicBag := bson.NewObjectID()
+	privateBag := bson.NewObjectID()
+	sharedBag := bson.NewObjectID()
+	bags := &fakeBagRepo{bags: map[bson.ObjectID]*bag.Bag{
+		publicBag:  {ID: publicBag, UserID: owner, Visibility: bag.VisibilityPublic},
+		privateBag: {ID: privateBag, UserID: owner, Visibility: bag.VisibilityPrivate},
+		sharedBag:  {ID: sharedBag, UserID: owner, Visibility: bag.VisibilityPrivate, ViewerIDs: []string{viewer}},
+	}}
+
+	discs := map[bson.ObjectID]*disc.Disc{}
+	add := func(name, status string, bagID bson.ObjectID) {
+		id := bson.NewObjectID()
+		discs[id] = &disc.Disc{ID: id, UserID: owner, Name: name, Status: status, BagID: bagID}
+	}
+	add("public", disc.StatusInBag, publicBag)
+	add("private", disc.StatusInBag, privateBag)
+	add("shared", disc.StatusInBag, sharedBag)
+	add("backup", disc.StatusBackup, bson.ObjectID{})
+	add("lost", disc.StatusLost, bson.ObjectID{})
+	add("sold from public", disc.StatusSold, publicBag)
+
+	tests := []struct {
+		caller string
+		want   []string
+	}{
+		{owner, []string{"backup", "lost", "private", "public", "shared", "sold from public"}},
+		{viewer, []string{"public", "shared"}},
+		{"stranger-1", []string{"public"}},
+		{"", []string{"public"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("caller "+tt.caller, func(t *testing.T) {
+			svc := NewDiscService(&fakeDiscRepo{discs: discs}, bags, nil, nil, nil, nil)
+
+			shelf, err := svc.GetShelf(context.Background(), owner, tt.caller)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got []string
+			for _, g := range shelf.Groups {
+				for _, d := range g.Discs {
+					got = append(got, d.Name)
+				}
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) || shelf.Total != len(tt.want) {
+				t.Errorf("shelf = %v (total %d), want %v", got, shelf.Total, tt.want)
+			}
+		})
+	}
+}
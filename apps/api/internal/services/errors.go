@@ -3,5 +3,32 @@ package services
 import "errors"
 
 var (
-	ErrNotFound = errors.New("resource not found")
+	ErrNotFound  = errors.New("resource not found")
+	ErrForbidden = errors.New("not authorized")
+
+	ErrUnknownLayout    = errors.New("no pars known for layout")
+	ErrRoundNotManual   = errors.New("round was not entered manually")
+	ErrRoundFinalized   = errors.New("round is already finalized")
+	ErrPlayerNotInRound = errors.New("player is not part of this round")
+	ErrDuplicatePlayer  = errors.New("player is already part of this round")
+	ErrInvalidHole      = errors.New("hole cannot be scored yet")
+	ErrInvalidScores    = errors.New("scores do not fit the round's layout")
+	ErrInvalidAlias     = errors.New("a player cannot be an alias of themselves")
+	ErrNoPlayerHistory  = errors.New("player has no complete rounds with pars")
+	ErrImportedRoundKey = errors.New("course and layout of an imported round come from the UDisc export and cannot be changed")
+
+	ErrEventHasNoRound = errors.New("event has no linked round")
+
+	ErrStatusUnchanged     = errors.New("disc already has that status")
+	ErrUnknownCourse       = errors.New("course not found in UDisc rounds")
+	ErrDiscNotLost         = errors.New("disc is not lost")
+	ErrInvalidRecoveryDate = errors.New("recovery date must be after the disc was lost")
+	ErrBagRequired         = errors.New("disc has no bag; a bagId is required")
+	ErrTooManyPhotos       = errors.New("disc already has the maximum number of photos")
+
+	ErrInvalidDeleteTarget = errors.New("discs cannot be moved into the bag being deleted")
+
+	ErrCatalogDiscNotFound = errors.New("catalog disc not found")
+	ErrCatalogDiscExists   = errors.New("a disc with this brand and name is already in the catalog")
+	ErrUnknownPlastic      = errors.New("plastic is not part of this brand's lineup")
 )
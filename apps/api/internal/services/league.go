@@ -0,0 +1,363 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Tidwell32/zack/apps/api/internal/league"
+	"github.com/Tidwell32/zack/apps/api/internal/repository"
+	"github.com/Tidwell32/zack/apps/api/internal/udisc"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var (
+	defaultPointsTable = []float64{10, 8, 6, 5, 4, 3, 2, 1}
+	defaultPayoutTable = []float64{0.5, 0.3, 0.2}
+)
+
+const (
+	defaultHandicapRounds  = 10
+	defaultHandicapPercent = 0.8
+)
+
+type LeagueService struct {
+	repo      repository.LeagueRepository
+	udiscRepo repository.UDiscRepository
+	aliasRepo repository.UDiscPlayerAliasRepository
+}
+
+func NewLeagueService(
+	repo repository.LeagueRepository,
+	udiscRepo repository.UDiscRepository,
+	aliasRepo repository.UDiscPlayerAliasRepository,
+) *LeagueService {
+	return &LeagueService{
+		repo:      repo,
+		udiscRepo: udiscRepo,
+		aliasRepo: aliasRepo,
+	}
+}
+
+func (s *LeagueService) CreateLeague(ctx context.Context, userID string, input league.CreateLeagueInput) (*league.League, error) {
+	now := time.Now().UTC()
+
+	l := &league.League{
+		ID:              bson.NewObjectID(),
+		UserID:          userID,
+		Name:            input.Name,
+		PointsTable:     input.PointsTable,
+		PayoutTable:     input.PayoutTable,
+		HandicapRounds:  input.HandicapRounds,
+		HandicapPercent: input.HandicapPercent,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if l.PointsTable == nil {
+		l.PointsTable = defaultPointsTable
+	}
+	if l.PayoutTable == nil {
+		l.PayoutTable = defaultPayoutTable
+	}
+	if l.HandicapRounds == 0 {
+		l.HandicapRounds = defaultHandicapRounds
+	}
+	if l.HandicapPercent == 0 {
+		l.HandicapPercent = defaultHandicapPercent
+	}
+
+	if err := s.repo.CreateLeague(ctx, l); err != nil {
+		return nil, fmt.Errorf("failed to create league: %w", err)
+	}
+
+	return l, nil
+}
+
+func (s *LeagueService) GetLeaguesForUser(ctx context.Context, userID string) ([]*league.League, error) {
+	return s.repo.FindLeaguesByUserID(ctx, userID)
+}
+
+func (s *LeagueService) GetLeague(ctx context.Context, userID string, leagueID bson.ObjectID) (*league.League, error) {
+	l, err := s.repo.FindLeagueByID(ctx, leagueID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if l == nil {
+		return nil, ErrNotFound
+	}
+
+	return l, nil
+}
+
+func (s *LeagueService) UpdateLeague(ctx context.Context, userID string, leagueID bson.ObjectID, input league.UpdateLeagueInput) (*league.League, error) {
+	l, err := s.GetLeague(ctx, userID, leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		l.Name = *input.Name
+	}
+	if input.PointsTable != nil {
+		l.PointsTable = input.PointsTable
+	}
+	if input.PayoutTable != nil {
+		l.PayoutTable = input.PayoutTable
+	}
+	if input.HandicapRounds != nil {
+		l.HandicapRounds = *input.HandicapRounds
+	}
+	if input.HandicapPercent != nil {
+		l.HandicapPercent = *input.HandicapPercent
+	}
+	l.UpdatedAt = time.Now().UTC()
+
+	if err := s.repo.UpdateLeague(ctx, l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (s *LeagueService) CreateSeason(ctx context.Context, userID string, leagueID bson.ObjectID, input league.CreateSeasonInput) (*league.Season, error) {
+	if _, err := s.GetLeague(ctx, userID, leagueID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	season := &league.Season{
+		ID:        bson.NewObjectID(),
+		UserID:    userID,
+		LeagueID:  leagueID,
+		Name:      input.Name,
+		StartDate: input.StartDate,
+		EndDate:   input.EndDate,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repo.CreateSeason(ctx, season); err != nil {
+		return nil, fmt.Errorf("failed to create season: %w", err)
+	}
+
+	return season, nil
+}
+
+func (s *LeagueService) GetSeasonsForLeague(ctx context.Context, userID string, leagueID bson.ObjectID) ([]*league.Season, error) {
+	if _, err := s.GetLeague(ctx, userID, leagueID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindSeasonsByLeagueID(ctx, leagueID, userID)
+}
+
+func (s *LeagueService) CreateEvent(ctx context.Context, userID string, seasonID bson.ObjectID, input league.CreateEventInput) (*league.Event, error) {
+	season, err := s.getSeason(ctx, userID, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	event := &league.Event{
+		ID:        bson.NewObjectID(),
+		UserID:    userID,
+		LeagueID:  season.LeagueID,
+		SeasonID:  season.ID,
+		Name:      input.Name,
+		Date:      input.Date,
+		Format:    input.Format,
+		EntryFee:  input.EntryFee,
+		Teams:     input.Teams,
+		Results:   []league.EventResult{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repo.CreateEvent(ctx, event); err != nil {
+		return nil, fmt.Errorf("failed to create event: %w", err)
+	}
+
+	return event, nil
+}
+
+func (s *LeagueService) GetEventsForSeason(ctx context.Context, userID string, seasonID bson.ObjectID) ([]*league.Event, error) {
+	if _, err := s.getSeason(ctx, userID, seasonID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindEventsBySeasonID(ctx, seasonID, userID)
+}
+
+func (s *LeagueService) GetEvent(ctx context.Context, userID string, eventID bson.ObjectID) (*league.Event, error) {
+	event, err := s.repo.FindEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return nil, ErrNotFound
+	}
+
+	return event, nil
+}
+
+// Links an imported or manually entered round to the event and scores it.
+// Passing a nil roundID rescores the round already linked, e.g. after a
+// correction. Teams, when given, replace the event's doubles teams.
+func (s *LeagueService) ScoreEvent(ctx context.Context, userID string, eventID bson.ObjectID, roundID *bson.ObjectID, teams []league.Team) (*league.Event, error) {
+	event, err := s.GetEvent(ctx, userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if roundID == nil {
+		roundID = event.RoundID
+	}
+	if roundID == nil {
+		return nil, ErrEventHasNoRound
+	}
+	if teams != nil {
+		event.Teams = teams
+	}
+
+	l, err := s.GetLeague(ctx, userID, event.LeagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	round, err := s.udiscRepo.GetRoundByID(ctx, *roundID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if round == nil {
+		return nil, ErrNotFound
+	}
+
+	history, err := s.udiscRepo.GetRoundsForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := s.aliasRepo.GetAliasesForUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load player aliases: %w", err)
+	}
+	aliasMap := udisc.NewAliasMap(aliases)
+	history = udisc.ApplyAliases(history, aliasMap)
+	linked := udisc.ApplyAliases([]udisc.Round{*round}, aliasMap)[0]
+
+	entrants := s.buildEntrants(l, event, linked, history, aliasMap)
+
+	now := time.Now().UTC()
+	event.RoundID = roundID
+	event.Results = league.ScoreEvent(l, entrants, event.EntryFee)
+	event.ScoredAt = &now
+	event.UpdatedAt = now
+
+	if err := s.repo.UpdateEventResults(ctx, event); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func (s *LeagueService) GetStandings(ctx context.Context, userID string, seasonID bson.ObjectID) ([]league.Standing, error) {
+	events, err := s.GetEventsForSeason(ctx, userID, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	scored := make([]league.Event, 0, len(events))
+	for _, e := range events {
+		if e.ScoredAt != nil {
+			scored = append(scored, *e)
+		}
+	}
+
+	return league.BuildStandings(scored), nil
+}
+
+func (s *LeagueService) getSeason(ctx context.Context, userID string, seasonID bson.ObjectID) (*league.Season, error) {
+	season, err := s.repo.FindSeasonByID(ctx, seasonID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if season == nil {
+		return nil, ErrNotFound
+	}
+
+	return season, nil
+}
+
+// Each complete card on the round is an entrant. Handicaps only look at rounds
+// played before this one, so rescoring an old event gives the same result.
+func (s *LeagueService) buildEntrants(l *league.League, event *league.Event, round udisc.Round, history []udisc.Round, aliases udisc.AliasMap) []league.Entrant {
+	prior := make([]udisc.Round, 0, len(history))
+	for _, r := range history {
+		if r.StartTime.Before(round.StartTime) && r.TotalPar > 0 && r.HoleCount > 0 {
+			prior = append(prior, r)
+		}
+	}
+	sort.Slice(prior, func(i, j int) bool {
+		return prior[i].StartTime.After(prior[j].StartTime)
+	})
+
+	handicapFor := func(name string) float64 {
+		perHole := make([]float64, 0)
+		for _, r := range prior {
+			for _, p := range r.Players {
+				if p.IsComplete && strings.EqualFold(strings.TrimSpace(p.PlayerName), name) {
+					perHole = append(perHole, float64(p.PlusMinusInt)/float64(r.HoleCount))
+					break
+				}
+			}
+		}
+		return league.Handicap(l, perHole, round.HoleCount)
+	}
+
+	entrants := make([]league.Entrant, 0, len(round.Players))
+	for _, p := range round.Players {
+		if !p.IsComplete {
+			continue
+		}
+
+		name := strings.TrimSpace(p.PlayerName)
+		members := []string{name}
+		if event.Format == league.FormatDoubles {
+			for _, t := range event.Teams {
+				if strings.EqualFold(strings.TrimSpace(t.Name), name) {
+					members = make([]string, 0, len(t.Players))
+					for _, m := range t.Players {
+						members = append(members, aliases.Resolve(m))
+					}
+					break
+				}
+			}
+		}
+
+		handicaps := make([]float64, len(members))
+		for i, m := range members {
+			handicaps[i] = handicapFor(m)
+		}
+
+		gross := 0
+		if p.Total != nil {
+			gross = *p.Total
+		} else {
+			for _, score := range p.Scores {
+				gross += score
+			}
+		}
+
+		entrants = append(entrants, league.Entrant{
+			Name:     name,
+			Players:  members,
+			Gross:    gross,
+			Handicap: league.TeamHandicap(handicaps),
+		})
+	}
+
+	return entrants
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Tidwell32/zack/apps/api/internal/catalog"
+)
+
+// SearchPlastics autocompletes plastic names, optionally within a brand.
+func (s *CatalogService) SearchPlastics(ctx context.Context, brandSlug, query string, limit int) ([]*catalog.Plastic, error) {
+	return s.plasticRepo.Search(ctx, brandSlug, strings.TrimSpace(query), limit)
+}
+
+// PlasticOptions lists the blends a catalog disc's brand makes, each with
+// the flight numbers the mold typically has in it.
+func (s *CatalogService) PlasticOptions(ctx context.Context, userID, id string) ([]catalog.PlasticOption, error) {
+	d, err := s.FindForUser(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, ErrCatalogDiscNotFound
+	}
+
+	plastics, err := s.plasticRepo.FindByBrand(ctx, d.BrandSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]catalog.PlasticOption, len(plastics))
+	for i, p := range plastics {
+		options[i] = catalog.PlasticOption{
+			Plastic:         *p,
+			SuggestedFlight: p.AdjustFlight(d.Flight()),
+		}
+	}
+	return options, nil
+}
+
+// ResolvePlastic checks a typed plastic name against the brand's lineup
+// and returns its canonical spelling. Brands missing from the registry
+// accept any name, since the registry only covers the major lineups.
+func (s *CatalogService) ResolvePlastic(ctx context.Context, brandSlug, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil
+	}
+
+	plastics, err := s.plasticRepo.FindByBrand(ctx, brandSlug)
+	if err != nil {
+		return "", err
+	}
+	if len(plastics) == 0 {
+		return name, nil
+	}
+
+	for _, p := range plastics {
+		if p.Matches(name) {
+			return p.Name, nil
+		}
+	}
+	return "", ErrUnknownPlastic
+}
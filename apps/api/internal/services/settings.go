@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/Tidwell32/zack/apps/api/internal/repository"
+	"github.com/Tidwell32/zack/apps/api/internal/settings"
+)
+
+type SettingsService struct {
+	repo repository.UserSettingsRepository
+}
+
+func NewSettingsService(repo repository.UserSettingsRepository) *SettingsService {
+	return &SettingsService{
+		repo: repo,
+	}
+}
+
+func (s *SettingsService) GetSettings(ctx context.Context, userID string) (*settings.UserSettings, error) {
+	return loadSettings(ctx, s.repo, userID)
+}
+
+func (s *SettingsService) UpdateSuggestPreferences(ctx context.Context, userID string, prefs settings.SuggestPreferences) (*settings.UserSettings, error) {
+	if err := prefs.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	us := &settings.UserSettings{
+		UserID:    userID,
+		Suggest:   prefs,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repo.Save(ctx, us); err != nil {
+		return nil, err
+	}
+
+	return us, nil
+}
+
+// loadSettings returns the user's stored settings, or the defaults when
+// they have never saved any.
+func loadSettings(ctx context.Context, repo repository.UserSettingsRepository, userID string) (*settings.UserSettings, error) {
+	if userID == "" {
+		return settings.Defaults(""), nil
+	}
+
+	us, err := repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if us == nil {
+		return settings.Defaults(userID), nil
+	}
+
+	return us, nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/csv"
 	"fmt"
+	"log"
 	"sort"
 	"strconv"
 	"strings"
@@ -16,12 +17,20 @@ import (
 )
 
 type UDiscService struct {
-	repo repository.UDiscRepository
+	repo            repository.UDiscRepository
+	aliasRepo       repository.UDiscPlayerAliasRepository
+	achievementRepo repository.UDiscAchievementRepository
 }
 
-func NewUDiscService(repo repository.UDiscRepository) *UDiscService {
+func NewUDiscService(
+	repo repository.UDiscRepository,
+	aliasRepo repository.UDiscPlayerAliasRepository,
+	achievementRepo repository.UDiscAchievementRepository,
+) *UDiscService {
 	return &UDiscService{
-		repo: repo,
+		repo:            repo,
+		aliasRepo:       aliasRepo,
+		achievementRepo: achievementRepo,
 	}
 }
 
@@ -139,7 +148,33 @@ func (s *UDiscService) ImportUDiscCSV(ctx context.Context, input ImportUDiscCSVI
 
 	persisted := false
 	if input.UserID != nil {
-		if err := s.repo.UpsertRounds(ctx, rounds); err != nil {
+		existing, err := s.repo.GetRoundsForUser(ctx, *input.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch rounds before import: %w", err)
+		}
+
+		// A UDisc export holds the whole history, so most rounds are already
+		// stored. Only new rounds and ones whose scores changed are written.
+		stored := make(map[string]udisc.Round, len(existing))
+		for _, r := range existing {
+			if r.Source == udisc.RoundSourceImport {
+				stored[r.ImportKey()] = r
+			}
+		}
+		var toSave, changed []udisc.Round
+		for _, r := range rounds {
+			old, ok := stored[r.ImportKey()]
+			if ok && r.SameImportContent(old) {
+				continue
+			}
+			toSave = append(toSave, r)
+			if ok {
+				changed = append(changed, old, r)
+			}
+		}
+
+		insertedIDs, err := s.repo.UpsertRounds(ctx, toSave)
+		if err != nil {
 			return nil, fmt.Errorf("failed to save rounds: %w", err)
 		}
 		persisted = true
@@ -148,7 +183,21 @@ func (s *UDiscService) ImportUDiscCSV(ctx context.Context, input ImportUDiscCSVI
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch rounds after import: %w", err)
 		}
-		rounds = allRounds
+
+		aliases, err := s.loadAliases(ctx, *input.UserID)
+		if err != nil {
+			return nil, err
+		}
+		rounds = udisc.ApplyAliases(allRounds, aliases)
+
+		// Re-imported rounds may carry corrected scores, so bests and streaks
+		// built from the old ones are rebuilt for the players in them.
+		if err := s.resetAchievements(ctx, *input.UserID, roundPlayers(udisc.ApplyAliases(changed, aliases))...); err != nil {
+			return nil, fmt.Errorf("failed to reset achievements after import: %w", err)
+		}
+		if err := s.applyRoundsToAchievements(ctx, *input.UserID, roundsWithIDs(rounds, insertedIDs)); err != nil {
+			log.Printf("warning: failed to update achievements after import: %v", err)
+		}
 	}
 
 	primaryPlayer := udisc.FindPrimaryPlayer(rounds)
@@ -326,6 +375,7 @@ func (s *UDiscService) buildRoundFromGroup(userID *string, group *roundGroup, no
 	round := udisc.Round{
 		ID:         bson.NewObjectID(),
 		UserID:     userIDVal,
+		Source:     udisc.RoundSourceImport,
 		CourseName: group.courseName,
 		LayoutName: group.layoutName,
 		StartTime:  group.startTime,
@@ -347,6 +397,12 @@ func (s *UDiscService) GetRoundsForUser(ctx context.Context, userID string, filt
 		return nil, err
 	}
 
+	aliases, err := s.loadAliases(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	rounds = udisc.ApplyAliases(rounds, aliases)
+
 	if loc == nil {
 		loc = time.UTC
 	}
@@ -417,11 +473,27 @@ func (s *UDiscService) filterRounds(rounds []udisc.Round, filters RoundFilters,
 }
 
 func (s *UDiscService) GetDistinctPlayersForUser(ctx context.Context, userID string) ([]string, error) {
-	names, err := s.repo.GetDistinctPlayers(ctx, userID)
+	rawNames, err := s.repo.GetDistinctPlayers(ctx, userID)
 	if err != nil {
 		return []string{}, err
 	}
 
+	aliases, err := s.loadAliases(ctx, userID)
+	if err != nil {
+		return []string{}, err
+	}
+
+	seen := make(map[string]struct{}, len(rawNames))
+	names := make([]string, 0, len(rawNames))
+	for _, raw := range rawNames {
+		name := aliases.Resolve(raw)
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+
 	sort.Strings(names)
 
 	if names == nil {
@@ -509,3 +581,645 @@ func extractDistinctCourses(rounds []udisc.Round) []udisc.CourseInfo {
 
 	return courses
 }
+
+func (s *UDiscService) GetRound(ctx context.Context, userID string, roundID bson.ObjectID) (*udisc.Round, error) {
+	round, err := s.repo.GetRoundByID(ctx, roundID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if round == nil {
+		return nil, ErrNotFound
+	}
+
+	return round, nil
+}
+
+func (s *UDiscService) CreateRound(ctx context.Context, userID string, input udisc.CreateRoundInput) (*udisc.Round, error) {
+	pars := input.Pars
+	if len(pars) == 0 {
+		// Picking an existing layout reuses the pars from the last time it was played
+		latest, err := s.repo.GetLatestRoundForLayout(ctx, userID, input.CourseName, input.LayoutName)
+		if err != nil {
+			return nil, err
+		}
+		if latest == nil {
+			return nil, ErrUnknownLayout
+		}
+		pars = latest.Pars
+	}
+
+	now := time.Now().UTC()
+	startTime := now
+	if input.StartTime != nil {
+		startTime = input.StartTime.UTC()
+	}
+
+	players := make([]udisc.PlayerScore, 0, len(input.PlayerNames))
+	seen := make(map[string]struct{}, len(input.PlayerNames))
+	for _, name := range input.PlayerNames {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if _, ok := seen[key]; ok {
+			return nil, ErrDuplicatePlayer
+		}
+		seen[key] = struct{}{}
+		players = append(players, udisc.ScorePlayer(name, []int{}, pars, len(pars)))
+	}
+
+	round := &udisc.Round{
+		ID:         bson.NewObjectID(),
+		UserID:     userID,
+		Source:     udisc.RoundSourceManual,
+		CourseName: input.CourseName,
+		LayoutName: input.LayoutName,
+		StartTime:  startTime,
+		EndTime:    startTime,
+		HoleCount:  len(pars),
+		Pars:       pars,
+		TotalPar:   udisc.CalculateTotalPar(pars),
+		Players:    players,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := s.repo.CreateRound(ctx, round); err != nil {
+		return nil, fmt.Errorf("failed to create round: %w", err)
+	}
+
+	return round, nil
+}
+
+func (s *UDiscService) AddPlayerToRound(ctx context.Context, userID string, roundID bson.ObjectID, playerName string) (*udisc.Round, error) {
+	round, err := s.getScorableRound(ctx, userID, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	if findPlayerIndex(round.Players, playerName) >= 0 {
+		return nil, ErrDuplicatePlayer
+	}
+
+	round.Players = append(round.Players, udisc.ScorePlayer(playerName, []int{}, round.Pars, round.HoleCount))
+	round.UpdatedAt = time.Now().UTC()
+
+	// Achievements leave out rounds still being scored, so there's nothing to reset
+	if err := s.repo.UpdateRound(ctx, round); err != nil {
+		return nil, err
+	}
+
+	return round, nil
+}
+
+func (s *UDiscService) RecordScore(ctx context.Context, userID string, roundID bson.ObjectID, input udisc.RecordScoreInput) (*udisc.Round, error) {
+	round, err := s.getScorableRound(ctx, userID, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := findPlayerIndex(round.Players, input.PlayerName)
+	if idx < 0 {
+		return nil, ErrPlayerNotInRound
+	}
+
+	// Scores stay a contiguous run of played holes, same as a UDisc export,
+	// so a hole can be corrected or the next one recorded but none skipped.
+	player := round.Players[idx]
+	if input.Hole < 1 || input.Hole > round.HoleCount || input.Hole > len(player.Scores)+1 {
+		return nil, ErrInvalidHole
+	}
+
+	scores := make([]int, len(player.Scores), round.HoleCount)
+	copy(scores, player.Scores)
+	if input.Hole == len(scores)+1 {
+		scores = append(scores, input.Score)
+	} else {
+		scores[input.Hole-1] = input.Score
+	}
+
+	now := time.Now().UTC()
+	round.Players[idx] = udisc.ScorePlayer(player.PlayerName, scores, round.Pars, round.HoleCount)
+	round.EndTime = now
+	round.UpdatedAt = now
+
+	if err := s.repo.UpdateRound(ctx, round); err != nil {
+		return nil, err
+	}
+
+	return round, nil
+}
+
+func (s *UDiscService) FinalizeRound(ctx context.Context, userID string, roundID bson.ObjectID) (*udisc.Round, error) {
+	round, err := s.getScorableRound(ctx, userID, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	for i, p := range round.Players {
+		round.Players[i] = udisc.ScorePlayer(p.PlayerName, p.Scores, round.Pars, round.HoleCount)
+	}
+	round.EndTime = now
+	round.FinalizedAt = &now
+	round.UpdatedAt = now
+
+	if err := s.repo.UpdateRound(ctx, round); err != nil {
+		return nil, err
+	}
+
+	aliases, err := s.loadAliases(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRoundsToAchievements(ctx, userID, udisc.ApplyAliases([]udisc.Round{*round}, aliases)); err != nil {
+		log.Printf("warning: failed to update achievements after finalizing round: %v", err)
+	}
+
+	return round, nil
+}
+
+func (s *UDiscService) getScorableRound(ctx context.Context, userID string, roundID bson.ObjectID) (*udisc.Round, error) {
+	round, err := s.repo.GetRoundByID(ctx, roundID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if round == nil {
+		return nil, ErrNotFound
+	}
+	if round.Source != udisc.RoundSourceManual {
+		return nil, ErrRoundNotManual
+	}
+	if round.FinalizedAt != nil {
+		return nil, ErrRoundFinalized
+	}
+
+	return round, nil
+}
+
+func findPlayerIndex(players []udisc.PlayerScore, name string) int {
+	target := strings.ToLower(strings.TrimSpace(name))
+	for i, p := range players {
+		if strings.ToLower(strings.TrimSpace(p.PlayerName)) == target {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *UDiscService) DeleteRound(ctx context.Context, userID string, roundID bson.ObjectID) error {
+	round, err := s.repo.GetRoundByID(ctx, roundID, userID)
+	if err != nil {
+		return err
+	}
+	if round == nil {
+		return ErrNotFound
+	}
+
+	if err := s.repo.DeleteRound(ctx, roundID, userID); err != nil {
+		return err
+	}
+
+	return s.resetRoundAchievements(ctx, userID, *round)
+}
+
+// Fixes the course, layout or pars of a round and rescores every player against
+// them. Course and layout are part of an imported round's import key, so they
+// can only be changed on manual rounds. Note that re-importing the same UDisc
+// export will overwrite corrections made to imported rounds.
+func (s *UDiscService) UpdateRound(ctx context.Context, userID string, roundID bson.ObjectID, input udisc.UpdateRoundInput) (*udisc.Round, error) {
+	round, err := s.repo.GetRoundByID(ctx, roundID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if round == nil {
+		return nil, ErrNotFound
+	}
+
+	if round.Source == udisc.RoundSourceImport &&
+		((input.CourseName != nil && *input.CourseName != round.CourseName) ||
+			(input.LayoutName != nil && *input.LayoutName != round.LayoutName)) {
+		return nil, ErrImportedRoundKey
+	}
+
+	if input.CourseName != nil {
+		round.CourseName = *input.CourseName
+	}
+	if input.LayoutName != nil {
+		round.LayoutName = *input.LayoutName
+	}
+	if input.Pars != nil {
+		for _, p := range round.Players {
+			if len(p.Scores) > len(input.Pars) {
+				return nil, ErrInvalidScores
+			}
+		}
+
+		round.Pars = input.Pars
+		round.HoleCount = len(input.Pars)
+		round.TotalPar = udisc.CalculateTotalPar(input.Pars)
+
+		for i, p := range round.Players {
+			rescored := udisc.ScorePlayer(p.PlayerName, p.Scores, round.Pars, round.HoleCount)
+			rescored.RoundRating = p.RoundRating
+			round.Players[i] = rescored
+		}
+	}
+	round.UpdatedAt = time.Now().UTC()
+
+	if err := s.repo.UpdateRound(ctx, round); err != nil {
+		return nil, err
+	}
+
+	if err := s.resetRoundAchievements(ctx, userID, *round); err != nil {
+		return nil, err
+	}
+
+	return round, nil
+}
+
+func (s *UDiscService) CorrectPlayer(ctx context.Context, userID string, roundID bson.ObjectID, playerName string, input udisc.CorrectPlayerInput) (*udisc.Round, error) {
+	round, err := s.repo.GetRoundByID(ctx, roundID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if round == nil {
+		return nil, ErrNotFound
+	}
+
+	idx := findPlayerIndex(round.Players, playerName)
+	if idx < 0 {
+		return nil, ErrPlayerNotInRound
+	}
+
+	existing := round.Players[idx]
+	name := existing.PlayerName
+	if input.PlayerName != nil {
+		if other := findPlayerIndex(round.Players, *input.PlayerName); other >= 0 && other != idx {
+			return nil, ErrDuplicatePlayer
+		}
+		name = *input.PlayerName
+	}
+
+	scores := existing.Scores
+	if input.Scores != nil {
+		if round.HoleCount > 0 && len(input.Scores) > round.HoleCount {
+			return nil, ErrInvalidScores
+		}
+		scores = input.Scores
+	}
+
+	corrected := udisc.ScorePlayer(name, scores, round.Pars, round.HoleCount)
+	corrected.RoundRating = existing.RoundRating
+	round.Players[idx] = corrected
+	round.UpdatedAt = time.Now().UTC()
+
+	if err := s.repo.UpdateRound(ctx, round); err != nil {
+		return nil, err
+	}
+
+	// A rename moves the round from one player's history to another's
+	before := udisc.Round{Players: []udisc.PlayerScore{existing}}
+	after := udisc.Round{Players: []udisc.PlayerScore{corrected}}
+	if err := s.resetRoundAchievements(ctx, userID, before, after); err != nil {
+		return nil, err
+	}
+
+	return round, nil
+}
+
+func (s *UDiscService) GetPlayerAliases(ctx context.Context, userID string) ([]udisc.PlayerAlias, error) {
+	return s.aliasRepo.GetAliasesForUser(ctx, userID)
+}
+
+// Merges alias into canonicalName across every round. Aliases of the merged
+// name are re-pointed so lookups never have to follow a chain.
+func (s *UDiscService) MergePlayers(ctx context.Context, userID, alias, canonicalName string) (*udisc.PlayerAlias, error) {
+	existing, err := s.aliasRepo.GetAliasesForUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load player aliases: %w", err)
+	}
+
+	alias = strings.TrimSpace(alias)
+	canonicalName = strings.TrimSpace(canonicalName)
+	if strings.EqualFold(alias, canonicalName) {
+		return nil, ErrInvalidAlias
+	}
+
+	aliasMap := udisc.NewAliasMap(existing)
+	canonical := aliasMap.Resolve(canonicalName)
+	// Rounds move between these players' histories, so their achievements are rebuilt
+	affected := []string{alias, aliasMap.Resolve(alias), canonicalName, canonical}
+	if strings.EqualFold(alias, canonical) {
+		// Reverse merge: canonicalName is currently an alias of alias. Drop
+		// that alias so canonicalName becomes the canonical player; everything
+		// pointing at alias is re-pointed below.
+		match := findAlias(existing, canonicalName)
+		if match == nil {
+			return nil, ErrInvalidAlias
+		}
+		if _, err := s.aliasRepo.DeleteAlias(ctx, userID, match.Alias); err != nil {
+			return nil, fmt.Errorf("failed to remove reversed alias: %w", err)
+		}
+		canonical = match.Alias
+	}
+
+	// Keep the spelling of an alias that already exists so it is updated in place
+	if match := findAlias(existing, alias); match != nil {
+		alias = match.Alias
+	}
+
+	pa := &udisc.PlayerAlias{
+		ID:            bson.NewObjectID(),
+		UserID:        userID,
+		Alias:         alias,
+		CanonicalName: canonical,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	if err := s.aliasRepo.UpsertAlias(ctx, pa); err != nil {
+		return nil, fmt.Errorf("failed to save alias: %w", err)
+	}
+
+	if err := s.aliasRepo.RepointAliases(ctx, userID, pa.Alias, canonical); err != nil {
+		return nil, fmt.Errorf("failed to re-point aliases: %w", err)
+	}
+
+	if err := s.resetAchievements(ctx, userID, append(affected, canonical)...); err != nil {
+		return nil, err
+	}
+
+	return pa, nil
+}
+
+func (s *UDiscService) DeletePlayerAlias(ctx context.Context, userID, alias string) error {
+	existing, err := s.aliasRepo.GetAliasesForUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load player aliases: %w", err)
+	}
+
+	match := findAlias(existing, alias)
+	if match == nil {
+		return ErrNotFound
+	}
+
+	deleted, err := s.aliasRepo.DeleteAlias(ctx, userID, match.Alias)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrNotFound
+	}
+
+	return s.resetAchievements(ctx, userID, match.Alias, match.CanonicalName)
+}
+
+func findAlias(aliases []udisc.PlayerAlias, alias string) *udisc.PlayerAlias {
+	alias = strings.TrimSpace(alias)
+	for i := range aliases {
+		if strings.EqualFold(aliases[i].Alias, alias) {
+			return &aliases[i]
+		}
+	}
+	return nil
+}
+
+func (s *UDiscService) loadAliases(ctx context.Context, userID string) (udisc.AliasMap, error) {
+	aliases, err := s.aliasRepo.GetAliasesForUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load player aliases: %w", err)
+	}
+
+	return udisc.NewAliasMap(aliases), nil
+}
+
+// Achievements are built from full history the first time they are requested
+// and then kept current as rounds are imported or finalized. Edits, deletes and
+// player merges discard them for the players involved so the next read rebuilds.
+func (s *UDiscService) GetPlayerAchievements(ctx context.Context, userID, playerName string) (*udisc.PlayerAchievements, error) {
+	aliases, err := s.loadAliases(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	name := aliases.Resolve(playerName)
+
+	existing, err := s.achievementRepo.GetForPlayer(ctx, userID, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	rounds, err := s.repo.GetRoundsForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	rounds = udisc.ApplyAliases(rounds, aliases)
+
+	// Stored under the player's spelling from their rounds, not the request's
+	canonical := ""
+	for _, r := range rounds {
+		if idx := findPlayerIndex(r.Players, name); idx >= 0 {
+			canonical = strings.TrimSpace(r.Players[idx].PlayerName)
+			break
+		}
+	}
+	if canonical == "" {
+		return nil, ErrNotFound
+	}
+
+	if canonical != name {
+		existing, err := s.achievementRepo.GetForPlayer(ctx, userID, canonical)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing, nil
+		}
+	}
+
+	sort.Slice(rounds, func(i, j int) bool {
+		return rounds[i].StartTime.Before(rounds[j].StartTime)
+	})
+
+	achievements := udisc.NewPlayerAchievements(userID, canonical)
+	for _, r := range rounds {
+		achievements.Apply(r)
+	}
+	achievements.UpdatedAt = time.Now().UTC()
+
+	if err := s.achievementRepo.Save(ctx, achievements); err != nil {
+		return nil, fmt.Errorf("failed to save achievements: %w", err)
+	}
+
+	return achievements, nil
+}
+
+// Folds newly added rounds into any achievements that have already been built.
+// A round older than what a player's achievements have seen can't be applied
+// incrementally, so those are discarded and rebuilt on the next read; one
+// starting at the same time has already been applied and is skipped.
+func (s *UDiscService) applyRoundsToAchievements(ctx context.Context, userID string, rounds []udisc.Round) error {
+	if len(rounds) == 0 {
+		return nil
+	}
+
+	sorted := make([]udisc.Round, len(rounds))
+	copy(sorted, rounds)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartTime.Before(sorted[j].StartTime)
+	})
+
+	states := make(map[string]*udisc.PlayerAchievements)
+	for _, r := range sorted {
+		for _, p := range r.Players {
+			name := strings.TrimSpace(p.PlayerName)
+			if name == "" {
+				continue
+			}
+
+			state, loaded := states[name]
+			if !loaded {
+				var err error
+				state, err = s.achievementRepo.GetForPlayer(ctx, userID, name)
+				if err != nil {
+					return err
+				}
+				states[name] = state
+			}
+			if state == nil {
+				continue
+			}
+
+			if r.StartTime.Before(state.LastRoundStart) {
+				if err := s.achievementRepo.DeleteForPlayer(ctx, userID, name); err != nil {
+					return err
+				}
+				states[name] = nil
+				continue
+			}
+			if !r.StartTime.After(state.LastRoundStart) {
+				// Already folded in, e.g. by a rebuild that ran after this round was saved
+				continue
+			}
+
+			state.Apply(r)
+		}
+	}
+
+	now := time.Now().UTC()
+	for _, state := range states {
+		if state == nil {
+			continue
+		}
+		state.UpdatedAt = now
+		if err := s.achievementRepo.Save(ctx, state); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resetAchievements discards the stored achievements of the named players so
+// the next read rebuilds them from full history.
+func (s *UDiscService) resetAchievements(ctx context.Context, userID string, playerNames ...string) error {
+	seen := make(map[string]struct{}, len(playerNames))
+	for _, name := range playerNames {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if _, ok := seen[key]; ok || key == "" {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		if err := s.achievementRepo.DeleteForPlayer(ctx, userID, strings.TrimSpace(name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resetRoundAchievements resets the players of the given rounds, under the
+// names their achievements are stored as.
+func (s *UDiscService) resetRoundAchievements(ctx context.Context, userID string, rounds ...udisc.Round) error {
+	aliases, err := s.loadAliases(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.resetAchievements(ctx, userID, roundPlayers(udisc.ApplyAliases(rounds, aliases))...)
+}
+
+func roundPlayers(rounds []udisc.Round) []string {
+	var names []string
+	for _, r := range rounds {
+		for _, p := range r.Players {
+			names = append(names, p.PlayerName)
+		}
+	}
+	return names
+}
+
+func roundsWithIDs(rounds []udisc.Round, ids []bson.ObjectID) []udisc.Round {
+	if len(ids) == 0 {
+		return []udisc.Round{}
+	}
+
+	wanted := make(map[bson.ObjectID]struct{}, len(ids))
+	for _, id := range ids {
+		wanted[id] = struct{}{}
+	}
+
+	result := make([]udisc.Round, 0, len(ids))
+	for _, r := range rounds {
+		if _, ok := wanted[r.ID]; ok {
+			result = append(result, r)
+		}
+	}
+
+	return result
+}
+
+// layout matches either a normalizedCourseId or a layout name, optionally
+// narrowed by course. Pars come from the most recent round on that layout.
+func (s *UDiscService) PredictScore(ctx context.Context, userID, layout, course, playerName string) (*udisc.Prediction, error) {
+	rounds, err := s.repo.GetRoundsForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := s.loadAliases(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	rounds = udisc.ApplyAliases(rounds, aliases)
+	playerName = aliases.Resolve(playerName)
+
+	views := udisc.NormalizeCourses(rounds)
+
+	var target *udisc.RoundView
+	for i := range views {
+		v := views[i]
+		if len(v.Pars) == 0 {
+			continue
+		}
+		if v.NormalizedCourseId != layout {
+			if !strings.EqualFold(strings.TrimSpace(v.LayoutName), layout) {
+				continue
+			}
+			if course != "" && !strings.EqualFold(strings.TrimSpace(v.CourseName), course) {
+				continue
+			}
+		}
+		if target == nil || v.StartTime.After(target.StartTime) {
+			target = &views[i]
+		}
+	}
+	if target == nil {
+		return nil, ErrUnknownLayout
+	}
+
+	prediction := udisc.PredictScore(views, *target, playerName)
+	if prediction == nil {
+		return nil, ErrNoPlayerHistory
+	}
+
+	return prediction, nil
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Tidwell32/zack/apps/api/internal/repository"
+	"github.com/Tidwell32/zack/apps/api/internal/udisc"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// fakeRoundRepo keeps rounds the way Mongo hands them back: every write and
+// read goes through BSON.
+type fakeRoundRepo struct {
+	repository.UDiscRepository
+	rounds  []udisc.Round
+	upserts int
+}
+
+func stored(r udisc.Round) udisc.Round {
+	data, err := bson.Marshal(r)
+	if err != nil {
+		panic(err)
+	}
+	var out udisc.Round
+	if err := bson.Unmarshal(data, &out); err != nil {
+		panic(err)
+	}
+	return out
+}
+
+func (r *fakeRoundRepo) UpsertRounds(_ context.Context, rounds []udisc.Round) ([]bson.ObjectID, error) {
+	inserted := []bson.ObjectID{}
+	for _, round := range rounds {
+		r.upserts++
+		replaced := false
+		for i, existing := range r.rounds {
+			if existing.Source == udisc.RoundSourceImport && existing.ImportKey() == round.ImportKey() {
+				round.ID, round.CreatedAt = existing.ID, existing.CreatedAt
+				r.rounds[i] = stored(round)
+				replaced = true
+			}
+		}
+		if !replaced {
+			round.ID = bson.NewObjectID()
+			r.rounds = append(r.rounds, stored(round))
+			inserted = append(inserted, round.ID)
+		}
+	}
+	return inserted, nil
+}
+
+func (r *fakeRoundRepo) CreateRound(_ context.Context, round *udisc.Round) error {
+	r.rounds = append(r.rounds, stored(*round))
+	return nil
+}
+
+func (r *fakeRoundRepo) UpdateRound(_ context.Context, round *udisc.Round) error {
+	for i := range r.rounds {
+		if r.rounds[i].ID == round.ID {
+			r.rounds[i] = stored(*round)
+		}
+	}
+	return nil
+}
+
+func (r *fakeRoundRepo) GetRoundsForUser(_ context.Context, userID string) ([]udisc.Round, error) {
+	var rounds []udisc.Round
+	for _, round := range r.rounds {
+		if round.UserID == userID {
+			rounds = append(rounds, round)
+		}
+	}
+	return rounds, nil
+}
+
+func (r *fakeRoundRepo) GetRoundByID(_ context.Context, id bson.ObjectID, userID string) (*udisc.Round, error) {
+	for _, round := range r.rounds {
+		if round.ID == id && round.UserID == userID {
+			return &round, nil
+		}
+	}
+	return nil, nil
+}
+
+type fakeAliasRepo struct {
+	repository.UDiscPlayerAliasRepository
+}
+
+func (r *fakeAliasRepo) GetAliasesForUser(context.Context, string) ([]udisc.PlayerAlias, error) {
+	return nil, nil
+}
+
+type fakeAchievementRepo struct {
+	saved map[string]*udisc.PlayerAchievements
+}
+
+func (r *fakeAchievementRepo) GetForPlayer(_ context.Context, _ string, playerName string) (*udisc.PlayerAchievements, error) {
+	if a, ok := r.saved[playerName]; ok {
+		cp := *a
+		return &cp, nil
+	}
+	return nil, nil
+}
+
+func (r *fakeAchievementRepo) Save(_ context.Context, a *udisc.PlayerAchievements) error {
+	cp := *a
+	r.saved[a.PlayerName] = &cp
+	return nil
+}
+
+func (r *fakeAchievementRepo) DeleteForPlayer(_ context.Context, _ string, playerName string) error {
+	for name := range r.saved {
+		if strings.EqualFold(name, playerName) {
+			delete(r.saved, name)
+		}
+	}
+	return nil
+}
+
+func (r *fakeAchievementRepo) DeleteForUser(context.Context, string) error {
+	clear(r.saved)
+	return nil
+}
+
+func newTestUDiscService() (*UDiscService, *fakeRoundRepo, *fakeAchievementRepo) {
+	rounds := &fakeRoundRepo{}
+	achievements := &fakeAchievementRepo{saved: map[string]*udisc.PlayerAchievements{}}
+	return NewUDiscService(rounds, &fakeAliasRepo{}, achievements), rounds, achievements
+}
+
+const roundsCSV = "PlayerName,CourseName,LayoutName,StartDate,EndDate,Total,+/-,Hole1,Hole2\n" +
+	"Par,Import Park,Main,2026-06-01 1000,2026-06-01 1100,6,,3,3\n" +
+	"Ace,Import Park,Main,2026-06-01 1000,2026-06-01 1100,%s\n" +
+	"Bo,Import Park,Main,2026-06-01 1000,2026-06-01 1100,6,E,3,3\n" +
+	"Cy,Other Park,Main,2026-06-02 1000,2026-06-02 1100,6,E,3,3\n"
+
+// Re-importing the same export keeps stored achievements; a changed round
+// only resets the players in it.
+func TestImportUDiscCSVResetsOnlyChangedPlayers(t *testing.T) {
+	svc, rounds, achievements := newTestUDiscService()
+	ctx := context.Background()
+	userID := "owner-1"
+
+	importCSV := func(aceCard string) {
+		t.Helper()
+		csv := strings.Replace(roundsCSV, "%s", aceCard, 1)
+		if _, err := svc.ImportUDiscCSV(ctx, ImportUDiscCSVInput{CSVData: []byte(csv), UserID: &userID, Timezone: time.UTC}); err != nil {
+			t.Fatalf("import failed: %v", err)
+		}
+	}
+
+	importCSV("7,+1,3,4")
+	for _, name := range []string{"Ace", "Bo", "Cy"} {
+		if _, err := svc.GetPlayerAchievements(ctx, userID, name); err != nil {
+			t.Fatalf("GetPlayerAchievements(%s): %v", name, err)
+		}
+	}
+
+	upserts := rounds.upserts
+	importCSV("7,+1,3,4")
+	if rounds.upserts != upserts {
+		t.Errorf("unchanged re-import wrote %d rounds", rounds.upserts-upserts)
+	}
+	if len(achievements.saved) != 3 {
+		t.Fatalf("unchanged re-import reset achievements: %v", achievements.saved)
+	}
+
+	importCSV("6,E,3,3")
+	for _, name := range []string{"Ace", "Bo"} {
+		if _, ok := achievements.saved[name]; ok {
+			t.Errorf("achievements of %s, who played the changed round, were kept", name)
+		}
+	}
+	if _, ok := achievements.saved["Cy"]; !ok {
+		t.Error("achievements of Cy, who didn't play the changed round, were reset")
+	}
+}
+
+// A manual round counts toward achievements once, when it is finalized,
+// however often achievements are read while it is being scored.
+func TestManualRoundCountsOnceWhenFinalized(t *testing.T) {
+	svc, _, achievements := newTestUDiscService()
+	ctx := context.Background()
+	userID := "owner-1"
+
+	round, err := svc.CreateRound(ctx, userID, udisc.CreateRoundInput{
+		CourseName:  "Home Park",
+		LayoutName:  "Main",
+		Pars:        []int{3, 3},
+		PlayerNames: []string{"Ace"},
+	})
+	if err != nil {
+		t.Fatalf("CreateRound: %v", err)
+	}
+
+	check := func(stage string, wantRounds, wantAces int) {
+		t.Helper()
+		a, err := svc.GetPlayerAchievements(ctx, userID, "Ace")
+		if err != nil {
+			t.Fatalf("%s: GetPlayerAchievements: %v", stage, err)
+		}
+		if a.RoundsPlayed != wantRounds || a.AceCount != wantAces {
+			t.Errorf("%s: RoundsPlayed = %d, AceCount = %d, want %d and %d", stage, a.RoundsPlayed, a.AceCount, wantRounds, wantAces)
+		}
+	}
+
+	for hole, score := range []int{1, 3} {
+		if _, err := svc.RecordScore(ctx, userID, round.ID, udisc.RecordScoreInput{PlayerName: "Ace", Hole: hole + 1, Score: score}); err != nil {
+			t.Fatalf("RecordScore: %v", err)
+		}
+		check("while scoring", 0, 0)
+	}
+
+	if _, err := svc.FinalizeRound(ctx, userID, round.ID); err != nil {
+		t.Fatalf("FinalizeRound: %v", err)
+	}
+	check("after finalizing", 1, 1)
+
+	clear(achievements.saved)
+	check("after a rebuild", 1, 1)
+}
+
+// Editing or deleting one round resets only the players in it.
+func TestRoundEditsResetOnlyItsPlayers(t *testing.T) {
+	ctx := context.Background()
+	userID := "owner-1"
+	csv := strings.Replace(roundsCSV, "%s", "7,+1,3,4", 1)
+
+	tests := []struct {
+		name  string
+		edit  func(svc *UDiscService, round udisc.Round) error
+		reset []string
+	}{
+		{"update pars", func(svc *UDiscService, round udisc.Round) error {
+			_, err := svc.UpdateRound(ctx, userID, round.ID, udisc.UpdateRoundInput{Pars: []int{3, 4}})
+			return err
+		}, []string{"Ace", "Bo"}},
+		{"correct a score", func(svc *UDiscService, round udisc.Round) error {
+			_, err := svc.CorrectPlayer(ctx, userID, round.ID, "Ace", udisc.CorrectPlayerInput{Scores: []int{3, 3}})
+			return err
+		}, []string{"Ace"}},
+		{"rename a player", func(svc *UDiscService, round udisc.Round) error {
+			name := "Cy"
+			_, err := svc.CorrectPlayer(ctx, userID, round.ID, "Ace", udisc.CorrectPlayerInput{PlayerName: &name})
+			return err
+		}, []string{"Ace", "Cy"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, rounds, achievements := newTestUDiscService()
+			if _, err := svc.ImportUDiscCSV(ctx, ImportUDiscCSVInput{CSVData: []byte(csv), UserID: &userID, Timezone: time.UTC}); err != nil {
+				t.Fatalf("import failed: %v", err)
+			}
+			for _, name := range []string{"Ace", "Bo", "Cy"} {
+				if _, err := svc.GetPlayerAchievements(ctx, userID, name); err != nil {
+					t.Fatalf("GetPlayerAchievements(%s): %v", name, err)
+				}
+			}
+
+			var importPark udisc.Round
+			for _, r := range rounds.rounds {
+				if r.CourseName == "Import Park" {
+					importPark = r
+				}
+			}
+			if err := tt.edit(svc, importPark); err != nil {
+				t.Fatalf("edit failed: %v", err)
+			}
+
+			for _, name := range []string{"Ace", "Bo", "Cy"} {
+				_, kept := achievements.saved[name]
+				if wantReset := slices.Contains(tt.reset, name); kept == wantReset {
+					t.Errorf("%s: kept = %v, want %v", name, kept, !wantReset)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package settings
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	MaxBrandPreferences = 50
+	MaxOwnedMoldPenalty = 20
+)
+
+var ErrBrandPreferredAndExcluded = errors.New("a brand cannot be both preferred and excluded")
+
+// DefaultPreferredBrands is the suggestion brand order for users who haven't
+// set their own. These were the site owner's favorites back when the list
+// was hard-coded.
+var DefaultPreferredBrands = []string{
+	"mvp",
+	"axiom-discs",
+	"mint-discs",
+	"kastaplast",
+	"innova",
+	"thought-space-athletics",
+	"latitude-64",
+	"discraft",
+	"westside-discs",
+}
+
+// UserSettings is one document per user holding their preferences.
+type UserSettings struct {
+	ID        bson.ObjectID      `bson:"_id,omitempty" json:"_id"`
+	UserID    string             `bson:"userId" json:"userId"`
+	Suggest   SuggestPreferences `bson:"suggest" json:"suggest"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// SuggestPreferences tune catalog suggestions.
+type SuggestPreferences struct {
+	// Brand slugs, most preferred first. Ties in flight distance go to
+	// preferred brands in this order.
+	PreferredBrands []string `bson:"preferredBrands" json:"preferredBrands"`
+	// Brand slugs never suggested.
+	ExcludedBrands []string `bson:"excludedBrands" json:"excludedBrands"`
+	// Added to the distance of molds the user already owns, so a higher
+	// value pushes them down the list without hiding them. Zero ranks owned
+	// molds like any other.
+	OwnedMoldPenalty float64 `bson:"ownedMoldPenalty" json:"ownedMoldPenalty"`
+}
+
+// Defaults returns the settings used for a user with no stored document.
+func Defaults(userID string) *UserSettings {
+	return &UserSettings{
+		UserID: userID,
+		Suggest: SuggestPreferences{
+			PreferredBrands: slices.Clone(DefaultPreferredBrands),
+			ExcludedBrands:  []string{},
+		},
+	}
+}
+
+// Validate checks brand lists that have already been normalized to slugs.
+func (p SuggestPreferences) Validate() error {
+	for _, b := range p.PreferredBrands {
+		if slices.Contains(p.ExcludedBrands, b) {
+			return ErrBrandPreferredAndExcluded
+		}
+	}
+	return nil
+}
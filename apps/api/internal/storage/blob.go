@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/Tidwell32/zack/apps/api/internal/config"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore holds binary objects such as disc photos under slash-separated
+// keys.
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Get returns the object and the content type it was stored with. The
+	// caller must close the reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, string, error)
+	// Delete succeeds when the key does not exist.
+	Delete(ctx context.Context, key string) error
+}
+
+// New builds the blob store selected by cfg.BlobStore.
+func New(cfg *config.Config) (BlobStore, error) {
+	if cfg.BlobStore == "s3" {
+		return NewS3BlobStore(S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretKey,
+			PathStyle:       cfg.S3UsePathStyle,
+		})
+	}
+	return NewLocalBlobStore(cfg.BlobLocalDir)
+}
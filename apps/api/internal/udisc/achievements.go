@@ -0,0 +1,262 @@
+package udisc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	MilestoneFirstUnderPar = "first_under_par"
+	MilestoneFirstAce      = "first_ace"
+	MilestoneRoundCount    = "round_count"
+)
+
+var roundCountMilestones = []int{10, 25, 50, 100, 250, 500, 1000}
+
+// Running achievement totals for a single player. Rounds must be applied in
+// chronological order; LastRoundStart lets callers tell when that no longer
+// holds and a rebuild is needed.
+type PlayerAchievements struct {
+	ID         bson.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID     string        `bson:"userId" json:"userId"`
+	PlayerName string        `bson:"playerName" json:"playerName"`
+
+	RoundsPlayed   int       `bson:"roundsPlayed" json:"roundsPlayed"`
+	AceCount       int       `bson:"aceCount" json:"aceCount"`
+	LastRoundStart time.Time `bson:"lastRoundStart" json:"lastRoundStart"`
+
+	PersonalBests       []PersonalBest `bson:"personalBests" json:"personalBests"`
+	LongestParStreak    *HoleStreak    `bson:"longestParStreak,omitempty" json:"longestParStreak,omitempty"`
+	LongestBirdieStreak *HoleStreak    `bson:"longestBirdieStreak,omitempty" json:"longestBirdieStreak,omitempty"`
+	Milestones          []Milestone    `bson:"milestones" json:"milestones"`
+
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// Best card on a layout group, grouped the same way NormalizeCourses does:
+// same course and hole count, within 2 par of the first round seen.
+type PersonalBest struct {
+	CourseName           string        `bson:"courseName" json:"courseName"`
+	LayoutName           string        `bson:"layoutName" json:"layoutName"`
+	NormalizedCourseName string        `bson:"normalizedCourseName" json:"normalizedCourseName"`
+	HoleCount            int           `bson:"holeCount" json:"holeCount"`
+	TotalPar             int           `bson:"totalPar" json:"totalPar"`
+	Total                int           `bson:"total" json:"total"`
+	PlusMinusInt         int           `bson:"plusMinusInt" json:"plusMinusInt"`
+	RoundID              bson.ObjectID `bson:"roundId" json:"roundId"`
+	PlayedAt             time.Time     `bson:"playedAt" json:"playedAt"`
+	TimesPlayed          int           `bson:"timesPlayed" json:"timesPlayed"`
+}
+
+// Consecutive holes within one round
+type HoleStreak struct {
+	Length     int           `bson:"length" json:"length"`
+	StartHole  int           `bson:"startHole" json:"startHole"`
+	RoundID    bson.ObjectID `bson:"roundId" json:"roundId"`
+	CourseName string        `bson:"courseName" json:"courseName"`
+	PlayedAt   time.Time     `bson:"playedAt" json:"playedAt"`
+}
+
+type Milestone struct {
+	Type        string        `bson:"type" json:"type"`
+	Description string        `bson:"description" json:"description"`
+	CourseName  string        `bson:"courseName,omitempty" json:"courseName,omitempty"`
+	RoundID     bson.ObjectID `bson:"roundId" json:"roundId"`
+	AchievedAt  time.Time     `bson:"achievedAt" json:"achievedAt"`
+}
+
+func NewPlayerAchievements(userID, playerName string) *PlayerAchievements {
+	return &PlayerAchievements{
+		ID:            bson.NewObjectID(),
+		UserID:        userID,
+		PlayerName:    playerName,
+		PersonalBests: []PersonalBest{},
+		Milestones:    []Milestone{},
+	}
+}
+
+// Folds one round into the totals. Rounds the player wasn't part of, and
+// manual rounds that are still being scored, are ignored.
+func (a *PlayerAchievements) Apply(r Round) {
+	if r.Source == RoundSourceManual && r.FinalizedAt == nil {
+		return
+	}
+
+	idx := -1
+	for i, p := range r.Players {
+		if strings.EqualFold(strings.TrimSpace(p.PlayerName), a.PlayerName) {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return
+	}
+	player := r.Players[idx]
+
+	if r.StartTime.After(a.LastRoundStart) {
+		a.LastRoundStart = r.StartTime
+	}
+
+	a.applyHoles(r, player)
+
+	if !player.IsComplete {
+		return
+	}
+
+	a.RoundsPlayed++
+	for _, n := range roundCountMilestones {
+		if a.RoundsPlayed == n {
+			a.Milestones = append(a.Milestones, Milestone{
+				Type:        MilestoneRoundCount,
+				Description: fmt.Sprintf("%s round", ordinal(n)),
+				RoundID:     r.ID,
+				AchievedAt:  r.StartTime,
+			})
+		}
+	}
+
+	hasPars := r.TotalPar > 0 && len(r.Pars) >= len(player.Scores)
+	if hasPars && player.PlusMinusInt < 0 && !a.hasUnderParOn(r.CourseName) {
+		a.Milestones = append(a.Milestones, Milestone{
+			Type:        MilestoneFirstUnderPar,
+			Description: fmt.Sprintf("First under par round at %s", r.CourseName),
+			CourseName:  r.CourseName,
+			RoundID:     r.ID,
+			AchievedAt:  r.StartTime,
+		})
+	}
+
+	a.applyPersonalBest(r, player)
+}
+
+func (a *PlayerAchievements) applyHoles(r Round, player PlayerScore) {
+	parRun, parStart := 0, 0
+	birdieRun, birdieStart := 0, 0
+
+	for i, score := range player.Scores {
+		if score == 1 {
+			a.AceCount++
+			if a.AceCount == 1 {
+				a.Milestones = append(a.Milestones, Milestone{
+					Type:        MilestoneFirstAce,
+					Description: fmt.Sprintf("First ace, hole %d at %s", i+1, r.CourseName),
+					CourseName:  r.CourseName,
+					RoundID:     r.ID,
+					AchievedAt:  r.StartTime,
+				})
+			}
+		}
+
+		if i >= len(r.Pars) || score == 0 {
+			parRun, birdieRun = 0, 0
+			continue
+		}
+		par := r.Pars[i]
+
+		if score <= par {
+			if parRun == 0 {
+				parStart = i + 1
+			}
+			parRun++
+			a.LongestParStreak = longerStreak(a.LongestParStreak, parRun, parStart, r)
+		} else {
+			parRun = 0
+		}
+
+		if score < par {
+			if birdieRun == 0 {
+				birdieStart = i + 1
+			}
+			birdieRun++
+			a.LongestBirdieStreak = longerStreak(a.LongestBirdieStreak, birdieRun, birdieStart, r)
+		} else {
+			birdieRun = 0
+		}
+	}
+}
+
+func (a *PlayerAchievements) applyPersonalBest(r Round, player PlayerScore) {
+	total := 0
+	if player.Total != nil {
+		total = *player.Total
+	} else {
+		for _, s := range player.Scores {
+			total += s
+		}
+	}
+
+	courseName := strings.TrimSpace(r.CourseName)
+	for i := range a.PersonalBests {
+		pb := &a.PersonalBests[i]
+		if pb.CourseName != courseName || pb.HoleCount != r.HoleCount || abs(pb.TotalPar-r.TotalPar) > 2 {
+			continue
+		}
+
+		pb.TimesPlayed++
+		if player.PlusMinusInt < pb.PlusMinusInt {
+			pb.LayoutName = r.LayoutName
+			pb.TotalPar = r.TotalPar
+			pb.Total = total
+			pb.PlusMinusInt = player.PlusMinusInt
+			pb.RoundID = r.ID
+			pb.PlayedAt = r.StartTime
+		}
+		return
+	}
+
+	a.PersonalBests = append(a.PersonalBests, PersonalBest{
+		CourseName:           courseName,
+		LayoutName:           r.LayoutName,
+		NormalizedCourseName: fmt.Sprintf("%s (%d holes, ~%d par)", courseName, r.HoleCount, r.TotalPar),
+		HoleCount:            r.HoleCount,
+		TotalPar:             r.TotalPar,
+		Total:                total,
+		PlusMinusInt:         player.PlusMinusInt,
+		RoundID:              r.ID,
+		PlayedAt:             r.StartTime,
+		TimesPlayed:          1,
+	})
+}
+
+func (a *PlayerAchievements) hasUnderParOn(courseName string) bool {
+	for _, m := range a.Milestones {
+		if m.Type == MilestoneFirstUnderPar && strings.EqualFold(m.CourseName, courseName) {
+			return true
+		}
+	}
+	return false
+}
+
+func longerStreak(current *HoleStreak, length, startHole int, r Round) *HoleStreak {
+	if current != nil && current.Length >= length {
+		return current
+	}
+	return &HoleStreak{
+		Length:     length,
+		StartHole:  startHole,
+		RoundID:    r.ID,
+		CourseName: r.CourseName,
+		PlayedAt:   r.StartTime,
+	}
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 100 {
+	case 11, 12, 13:
+	default:
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
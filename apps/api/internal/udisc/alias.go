@@ -0,0 +1,60 @@
+package udisc
+
+import (
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Maps an alternate spelling of a player's name ("Zack T") onto the name
+// it should be reported as ("Zack Tidwell").
+type PlayerAlias struct {
+	ID            bson.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID        string        `bson:"userId" json:"userId"`
+	Alias         string        `bson:"alias" json:"alias"`
+	CanonicalName string        `bson:"canonicalName" json:"canonicalName"`
+	CreatedAt     time.Time     `bson:"createdAt" json:"createdAt"`
+}
+
+type AliasMap map[string]string
+
+func NewAliasMap(aliases []PlayerAlias) AliasMap {
+	m := make(AliasMap, len(aliases))
+	for _, a := range aliases {
+		m[aliasKey(a.Alias)] = strings.TrimSpace(a.CanonicalName)
+	}
+	return m
+}
+
+func (m AliasMap) Resolve(name string) string {
+	if canonical, ok := m[aliasKey(name)]; ok {
+		return canonical
+	}
+	return strings.TrimSpace(name)
+}
+
+// Rewrites player names to their canonical form. Rounds are copied so the
+// caller's slice is left untouched.
+func ApplyAliases(rounds []Round, aliases AliasMap) []Round {
+	if len(aliases) == 0 {
+		return rounds
+	}
+
+	result := make([]Round, len(rounds))
+	for i, r := range rounds {
+		players := make([]PlayerScore, len(r.Players))
+		for j, p := range r.Players {
+			p.PlayerName = aliases.Resolve(p.PlayerName)
+			players[j] = p
+		}
+		r.Players = players
+		result[i] = r
+	}
+
+	return result
+}
+
+func aliasKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,291 @@
+package udisc
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	// Recency half-lives, counted in rounds
+	formHalfLife   = 10.0
+	layoutHalfLife = 5.0
+
+	predictionConfidence = 0.9
+	predictionZ          = 1.645
+
+	// Floor on round-to-round noise so a handful of similar rounds can't
+	// produce an implausibly tight interval
+	minRoundSigma = 2.0
+)
+
+type Prediction struct {
+	PlayerName           string `json:"playerName"`
+	CourseName           string `json:"courseName"`
+	LayoutName           string `json:"layoutName"`
+	NormalizedCourseId   string `json:"normalizedCourseId"`
+	NormalizedCourseName string `json:"normalizedCourseName"`
+	HoleCount            int    `json:"holeCount"`
+	TotalPar             int    `json:"totalPar"`
+
+	ExpectedScore     float64 `json:"expectedScore"`
+	ExpectedPlusMinus float64 `json:"expectedPlusMinus"`
+	Low               float64 `json:"low"`
+	High              float64 `json:"high"`
+	ConfidenceLevel   float64 `json:"confidenceLevel"`
+
+	// Strokes over par per hole the player is currently trending at, anywhere
+	FormPerHole float64 `json:"formPerHole"`
+	// Change in FormPerHole per round played, negative means improving
+	FormTrendPerRound float64 `json:"formTrendPerRound"`
+
+	RoundsOnLayout   int              `json:"roundsOnLayout"`
+	RoundsConsidered int              `json:"roundsConsidered"`
+	Holes            []HoleDifficulty `json:"holes"`
+}
+
+type HoleDifficulty struct {
+	Hole          int      `json:"hole"`
+	Par           int      `json:"par"`
+	FieldAverage  *float64 `json:"fieldAverage,omitempty"`
+	PlayerAverage *float64 `json:"playerAverage,omitempty"`
+}
+
+// Predicts a player's next score on target's layout group.
+//
+// A prior comes from the player's current form (a recency-weighted linear fit
+// of strokes over par per hole across every course) plus how much harder the
+// layout's holes play for the field than the field's usual. That prior is then
+// updated with the player's own rounds on the layout, detrended to current form,
+// as a normal-normal model. Rounds must already have aliases applied.
+//
+// Returns nil if the player has no complete rounds with pars to learn from.
+func PredictScore(views []RoundView, target RoundView, playerName string) *Prediction {
+	holes := len(target.Pars)
+	if holes == 0 {
+		return nil
+	}
+
+	// Player history anywhere, oldest first
+	history := make([]scoredCard, 0)
+	for _, v := range views {
+		if card, ok := playerCard(v, playerName); ok {
+			history = append(history, card)
+		}
+	}
+	if len(history) == 0 {
+		return nil
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].view.StartTime.Before(history[j].view.StartTime)
+	})
+
+	xs := make([]float64, len(history))
+	ys := make([]float64, len(history))
+	ws := make([]float64, len(history))
+	for i, c := range history {
+		xs[i] = float64(i)
+		ys[i] = c.perHole
+		ws[i] = math.Pow(0.5, float64(len(history)-1-i)/formHalfLife)
+	}
+	intercept, slope := weightedLinearFit(xs, ys, ws)
+	formAt := func(i int) float64 { return intercept + slope*float64(i) }
+	formNow := formAt(len(history) - 1)
+
+	sumW, sumSq := 0.0, 0.0
+	for i := range history {
+		resid := (ys[i] - formAt(i)) * float64(holes)
+		sumW += ws[i]
+		sumSq += ws[i] * resid * resid
+	}
+	sigma := math.Sqrt(sumSq / sumW)
+	if math.IsNaN(sigma) || sigma < minRoundSigma {
+		sigma = minRoundSigma
+	}
+
+	holeStats := holeDifficulty(views, target, playerName)
+	difficultyAdj := 0.0
+	if baseline, ok := fieldBaselinePerHole(views); ok {
+		for _, h := range holeStats {
+			if h.FieldAverage != nil {
+				difficultyAdj += (*h.FieldAverage - float64(h.Par)) - baseline
+			}
+		}
+	}
+
+	priorMean := formNow*float64(holes) + difficultyAdj
+	priorVar := sigma * sigma
+
+	// Player's own rounds on this layout group, shifted to today's form
+	layoutCount := 0
+	layoutSumW, layoutSumWY := 0.0, 0.0
+	onLayout := make([]int, 0)
+	for i, c := range history {
+		if c.view.NormalizedCourseId == target.NormalizedCourseId {
+			onLayout = append(onLayout, i)
+		}
+	}
+	for k, i := range onLayout {
+		c := history[i]
+		y := float64(c.player.PlusMinusInt) + (formNow-formAt(i))*float64(c.view.HoleCount)
+		w := math.Pow(0.5, float64(len(onLayout)-1-k)/layoutHalfLife)
+		layoutSumW += w
+		layoutSumWY += w * y
+		layoutCount++
+	}
+
+	postPrec := 1/priorVar + layoutSumW/(sigma*sigma)
+	postMean := (priorMean/priorVar + layoutSumWY/(sigma*sigma)) / postPrec
+	predictiveSD := math.Sqrt(sigma*sigma + 1/postPrec)
+
+	totalPar := float64(CalculateTotalPar(target.Pars))
+	expected := totalPar + postMean
+
+	return &Prediction{
+		PlayerName:           strings.TrimSpace(history[0].player.PlayerName),
+		CourseName:           target.CourseName,
+		LayoutName:           target.LayoutName,
+		NormalizedCourseId:   target.NormalizedCourseId,
+		NormalizedCourseName: target.NormalizedCourseName,
+		HoleCount:            holes,
+		TotalPar:             int(totalPar),
+		ExpectedScore:        round1(expected),
+		ExpectedPlusMinus:    round1(postMean),
+		Low:                  round1(expected - predictionZ*predictiveSD),
+		High:                 round1(expected + predictionZ*predictiveSD),
+		ConfidenceLevel:      predictionConfidence,
+		FormPerHole:          round3(formNow),
+		FormTrendPerRound:    round3(slope),
+		RoundsOnLayout:       layoutCount,
+		RoundsConsidered:     len(history),
+		Holes:                holeStats,
+	}
+}
+
+type scoredCard struct {
+	view    RoundView
+	player  PlayerScore
+	perHole float64
+}
+
+func playerCard(v RoundView, playerName string) (scoredCard, bool) {
+	if v.TotalPar == 0 || v.HoleCount == 0 {
+		return scoredCard{}, false
+	}
+	for _, p := range v.Players {
+		if !p.IsComplete || !strings.EqualFold(strings.TrimSpace(p.PlayerName), playerName) {
+			continue
+		}
+		return scoredCard{
+			view:    v,
+			player:  p,
+			perHole: float64(p.PlusMinusInt) / float64(v.HoleCount),
+		}, true
+	}
+	return scoredCard{}, false
+}
+
+// Average strokes per hole for everyone on the layout group, plus the
+// player's own average, for rounds that share the target's hole count
+func holeDifficulty(views []RoundView, target RoundView, playerName string) []HoleDifficulty {
+	holes := len(target.Pars)
+	fieldSum := make([]float64, holes)
+	fieldN := make([]int, holes)
+	playerSum := make([]float64, holes)
+	playerN := make([]int, holes)
+
+	for _, v := range views {
+		if v.NormalizedCourseId != target.NormalizedCourseId || v.HoleCount != holes {
+			continue
+		}
+		for _, p := range v.Players {
+			isPlayer := strings.EqualFold(strings.TrimSpace(p.PlayerName), playerName)
+			for h, score := range p.Scores {
+				if h >= holes || score <= 0 {
+					continue
+				}
+				fieldSum[h] += float64(score)
+				fieldN[h]++
+				if isPlayer {
+					playerSum[h] += float64(score)
+					playerN[h]++
+				}
+			}
+		}
+	}
+
+	result := make([]HoleDifficulty, holes)
+	for h := 0; h < holes; h++ {
+		hd := HoleDifficulty{Hole: h + 1, Par: target.Pars[h]}
+		if fieldN[h] > 0 {
+			avg := round3(fieldSum[h] / float64(fieldN[h]))
+			hd.FieldAverage = &avg
+		}
+		if playerN[h] > 0 {
+			avg := round3(playerSum[h] / float64(playerN[h]))
+			hd.PlayerAverage = &avg
+		}
+		result[h] = hd
+	}
+
+	return result
+}
+
+// Strokes over par per hole for every complete card with pars, so layout
+// difficulty is measured relative to how this group usually plays
+func fieldBaselinePerHole(views []RoundView) (float64, bool) {
+	sum, n := 0.0, 0
+	for _, v := range views {
+		if v.TotalPar == 0 || v.HoleCount == 0 {
+			continue
+		}
+		for _, p := range v.Players {
+			if !p.IsComplete {
+				continue
+			}
+			sum += float64(p.PlusMinusInt)
+			n += v.HoleCount
+		}
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return sum / float64(n), true
+}
+
+func weightedLinearFit(xs, ys, ws []float64) (intercept, slope float64) {
+	sumW, sumX, sumY := 0.0, 0.0, 0.0
+	for i := range xs {
+		sumW += ws[i]
+		sumX += ws[i] * xs[i]
+		sumY += ws[i] * ys[i]
+	}
+	meanX := sumX / sumW
+	meanY := sumY / sumW
+
+	if len(xs) < 3 {
+		return meanY, 0
+	}
+
+	sxx, sxy := 0.0, 0.0
+	for i := range xs {
+		dx := xs[i] - meanX
+		sxx += ws[i] * dx * dx
+		sxy += ws[i] * dx * (ys[i] - meanY)
+	}
+	if sxx == 0 {
+		return meanY, 0
+	}
+
+	slope = sxy / sxx
+	return meanY - slope*meanX, slope
+}
+
+func round1(v float64) float64 {
+	// Adding 0 turns -0 into 0 so JSON never shows "-0"
+	return math.Round(v*10)/10 + 0
+}
+
+func round3(v float64) float64 {
+	return math.Round(v*1000)/1000 + 0
+}
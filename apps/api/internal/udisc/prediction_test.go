@@ -0,0 +1,145 @@
+package udisc
+
+import (
+	"testing"
+	"time"
+)
+
+// card builds a complete two-hole scorecard on par 3s.
+func card(name string, scores ...int) PlayerScore {
+	return PlayerScore{
+		PlayerName:   name,
+		PlusMinusInt: scores[0] + scores[1] - 6,
+		Scores:       scores,
+		HoleCount:    2,
+		IsComplete:   true,
+	}
+}
+
+func predictionView(courseID string, day int, players ...PlayerScore) RoundView {
+	return RoundView{
+		Round: Round{
+			CourseName: courseID + " Park",
+			LayoutName: "Main",
+			StartTime:  time.Date(2026, 6, day, 10, 0, 0, 0, time.UTC),
+			HoleCount:  2,
+			Pars:       []int{3, 3},
+			TotalPar:   6,
+			Players:    players,
+		},
+		NormalizedCourseId: courseID,
+	}
+}
+
+func TestPredictScore(t *testing.T) {
+	target := func(courseID string) RoundView { return predictionView(courseID, 30) }
+
+	incomplete := card("Ace", 3, 3)
+	incomplete.IsComplete = false
+
+	tests := []struct {
+		name   string
+		views  []RoundView
+		target RoundView
+		player string
+		want   *Prediction
+	}{
+		{
+			name:   "no pars on the target",
+			views:  []RoundView{predictionView("a", 1, card("Ace", 3, 3))},
+			target: RoundView{NormalizedCourseId: "a"},
+			player: "Ace",
+		},
+		{
+			name:   "no rounds for the player",
+			views:  []RoundView{predictionView("a", 1, card("Bo", 3, 3))},
+			target: target("a"),
+			player: "Ace",
+		},
+		{
+			name:   "only incomplete cards",
+			views:  []RoundView{predictionView("a", 1, incomplete)},
+			target: target("a"),
+			player: "Ace",
+		},
+		{
+			// Names match case-insensitively and ignore padding. Three
+			// identical rounds leave the estimate at +1, with the noise
+			// floor and the layout rounds setting the interval.
+			name: "steady player on the layout",
+			views: []RoundView{
+				predictionView("a", 1, card("ACE ", 3, 4)),
+				predictionView("a", 2, card("ACE ", 3, 4)),
+				predictionView("a", 3, card("ACE ", 3, 4)),
+			},
+			target: target("a"),
+			player: "ace",
+			want: &Prediction{
+				PlayerName: "ACE", ExpectedScore: 7, ExpectedPlusMinus: 1, Low: 3.3, High: 10.7,
+				FormPerHole: 0.5, RoundsOnLayout: 3, RoundsConsidered: 3,
+			},
+		},
+		{
+			// +4, +2, even: the fitted form is even today, and detrending
+			// brings every layout round to even as well.
+			name: "improving player",
+			views: []RoundView{
+				predictionView("a", 1, card("Ace", 5, 5)),
+				predictionView("a", 2, card("Ace", 4, 4)),
+				predictionView("a", 3, card("Ace", 3, 3)),
+			},
+			target: target("a"),
+			player: "Ace",
+			want: &Prediction{
+				PlayerName: "Ace", ExpectedScore: 6, ExpectedPlusMinus: 0, Low: 2.3, High: 9.7,
+				FormPerHole: 0, FormTrendPerRound: -1, RoundsOnLayout: 3, RoundsConsidered: 3,
+			},
+		},
+		{
+			// Never played the target, where the field averages 5s against
+			// a usual +0.5 per hole: 1.5 strokes harder on each hole.
+			name: "harder layout the player hasn't played",
+			views: []RoundView{
+				predictionView("easy", 1, card("Ace", 3, 3)),
+				predictionView("easy", 2, card("Ace", 3, 3)),
+				predictionView("easy", 3, card("Ace", 3, 3)),
+				predictionView("hard", 4, card("Bo", 5, 5)),
+			},
+			target: target("hard"),
+			player: "Ace",
+			want: &Prediction{
+				PlayerName: "Ace", ExpectedScore: 9, ExpectedPlusMinus: 3, Low: 4.3, High: 13.7,
+				FormPerHole: 0, RoundsOnLayout: 0, RoundsConsidered: 3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PredictScore(tt.views, tt.target, tt.player)
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("PredictScore = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("PredictScore = nil")
+			}
+
+			if got.PlayerName != tt.want.PlayerName ||
+				got.ExpectedScore != tt.want.ExpectedScore ||
+				got.ExpectedPlusMinus != tt.want.ExpectedPlusMinus ||
+				got.Low != tt.want.Low || got.High != tt.want.High ||
+				got.FormPerHole != tt.want.FormPerHole ||
+				got.FormTrendPerRound != tt.want.FormTrendPerRound ||
+				got.RoundsOnLayout != tt.want.RoundsOnLayout ||
+				got.RoundsConsidered != tt.want.RoundsConsidered {
+				t.Errorf("PredictScore = %+v, want %+v", got, tt.want)
+			}
+			if got.TotalPar != 6 || got.HoleCount != 2 || len(got.Holes) != 2 || got.ConfidenceLevel != predictionConfidence {
+				t.Errorf("target summary = par %d, %d holes, %d hole stats, confidence %g", got.TotalPar, got.HoleCount, len(got.Holes), got.ConfidenceLevel)
+			}
+		})
+	}
+}
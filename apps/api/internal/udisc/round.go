@@ -19,9 +19,15 @@ type PlayerScore struct {
 	IsComplete bool  `bson:"isComplete" json:"isComplete"`
 }
 
+const (
+	RoundSourceImport = "import"
+	RoundSourceManual = "manual"
+)
+
 type Round struct {
 	ID     bson.ObjectID `bson:"_id,omitempty" json:"_id"`
 	UserID string        `bson:"userId" json:"userId"`
+	Source string        `bson:"source" json:"source"`
 
 	CourseName string `bson:"courseName" json:"courseName"`
 	LayoutName string `bson:"layoutName" json:"layoutName"`
@@ -35,6 +41,9 @@ type Round struct {
 
 	Players []PlayerScore `bson:"players" json:"players"`
 
+	// Only set for manually entered rounds once scoring is done
+	FinalizedAt *time.Time `bson:"finalizedAt,omitempty" json:"finalizedAt,omitempty"`
+
 	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
 	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
 }
@@ -73,6 +82,31 @@ type RoundCSVRow struct {
 	Hole21 string `csv:"Hole21"`
 }
 
+type CreateRoundInput struct {
+	CourseName  string
+	LayoutName  string
+	Pars        []int
+	PlayerNames []string
+	StartTime   *time.Time
+}
+
+type RecordScoreInput struct {
+	PlayerName string
+	Hole       int // 1-based
+	Score      int
+}
+
+type UpdateRoundInput struct {
+	CourseName *string
+	LayoutName *string
+	Pars       []int // nil leaves pars unchanged
+}
+
+type CorrectPlayerInput struct {
+	PlayerName *string // renames the player on this round only
+	Scores     []int   // nil leaves scores unchanged
+}
+
 type RoundsResponse struct {
 	Rounds        []RoundView `json:"rounds"`
 	PrimaryPlayer string      `json:"primaryPlayer"`
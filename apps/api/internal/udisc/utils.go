@@ -1,10 +1,13 @@
 package udisc
 
 import (
+	"bytes"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
 func FindPrimaryPlayer(rounds []Round) string {
@@ -106,6 +109,49 @@ func ParsePlusMinus(plusMinus string, total *int, totalPar int) int {
 	return val
 }
 
+// Recomputes the derived fields of a player's card from their hole scores,
+// matching the shape that CSV imports produce.
+func ScorePlayer(name string, scores []int, pars []int, holeCount int) PlayerScore {
+	total := 0
+	playedPar := 0
+	for i, s := range scores {
+		total += s
+		if i < len(pars) {
+			playedPar += pars[i]
+		}
+	}
+
+	var totalPtr *int
+	plusMinusInt := 0
+	if len(scores) > 0 {
+		totalPtr = &total
+		// Without pars for every played hole there's nothing to compare against
+		if len(pars) >= len(scores) {
+			plusMinusInt = total - playedPar
+		}
+	}
+
+	return PlayerScore{
+		PlayerName:   strings.TrimSpace(name),
+		Total:        totalPtr,
+		PlusMinus:    FormatPlusMinus(plusMinusInt),
+		PlusMinusInt: plusMinusInt,
+		Scores:       scores,
+		HoleCount:    len(scores),
+		IsComplete:   IsRoundComplete(scores, holeCount),
+	}
+}
+
+func FormatPlusMinus(plusMinus int) string {
+	if plusMinus == 0 {
+		return "E"
+	}
+	if plusMinus > 0 {
+		return fmt.Sprintf("+%d", plusMinus)
+	}
+	return strconv.Itoa(plusMinus)
+}
+
 func CalculateTotalPar(pars []int) int {
 	total := 0
 	for _, p := range pars {
@@ -185,3 +231,41 @@ func abs(n int) int {
 	}
 	return n
 }
+
+// ImportKey identifies an imported round the way a re-import matches it.
+func (r Round) ImportKey() string {
+	return r.CourseName + "\x00" + r.LayoutName + "\x00" + strconv.FormatInt(r.StartTime.UnixMilli(), 10)
+}
+
+// SameImportContent reports whether re-importing r over stored would leave
+// the stored round unchanged. Both are compared as they are stored, so a
+// round read back from the database matches the one it was imported from.
+func (r Round) SameImportContent(stored Round) bool {
+	a, errA := bson.Marshal(importContent(r))
+	b, errB := bson.Marshal(importContent(stored))
+	return errA == nil && errB == nil && bytes.Equal(a, b)
+}
+
+type importedFields struct {
+	EndTime   time.Time     `bson:"endTime"`
+	HoleCount int           `bson:"holeCount"`
+	Pars      []int         `bson:"pars"`
+	TotalPar  int           `bson:"totalPar"`
+	Players   []PlayerScore `bson:"players"`
+}
+
+func importContent(r Round) importedFields {
+	// Pars are omitted when empty, so they read back as nil
+	pars := r.Pars
+	if len(pars) == 0 {
+		pars = nil
+	}
+
+	return importedFields{
+		EndTime:   r.EndTime,
+		HoleCount: r.HoleCount,
+		Pars:      pars,
+		TotalPar:  r.TotalPar,
+		Players:   r.Players,
+	}
+}
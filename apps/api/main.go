@@ -15,6 +15,7 @@ import (
 	"github.com/Tidwell32/zack/apps/api/internal/database"
 	"github.com/Tidwell32/zack/apps/api/internal/migrations"
 	"github.com/Tidwell32/zack/apps/api/internal/seeding"
+	"github.com/Tidwell32/zack/apps/api/internal/storage"
 )
 
 func main() {
@@ -35,6 +36,15 @@ func main() {
 		}
 	}()
 
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := db.RequireReplicaSet(ctx); err != nil {
+			log.Fatalf("failed to start: %v", err)
+		}
+	}
+
 	// Run migrations
 	{
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -50,15 +60,40 @@ func main() {
 		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 		defer cancel()
 
-		if err := seeding.SeedDiscCatalog(ctx, db.Database, cfg.ForceReseed); err != nil {
+		source, err := seeding.NewCatalogSource(cfg)
+		if err != nil {
+			log.Fatalf("failed to set up catalog source: %v", err)
+		}
+
+		if err := seeding.SeedDiscCatalog(ctx, db.Database, source, cfg.ForceReseed); err != nil {
 			log.Printf("warning: failed to seed disc catalog: %v", err)
 			// Don't fatal - allow API to start even if seeding fails
 		}
+
+		if _, err := seeding.SeedPlastics(ctx, db.Database, cfg.PlasticsFile); err != nil {
+			log.Printf("warning: failed to seed plastics: %v", err)
+		}
 	}
 
-	application := app.New(cfg, db)
+	blobs, err := storage.New(cfg)
+	if err != nil {
+		log.Fatalf("failed to set up blob storage: %v", err)
+	}
+
+	application := app.New(cfg, db, blobs)
 	handler := application.Routes()
 
+	// Build the search index from the freshly seeded catalog before serving
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := application.LoadCatalogIndex(ctx); err != nil {
+			log.Printf("warning: failed to load catalog index: %v", err)
+			// Searches load it on first use instead
+		}
+	}
+
 	port := ":" + cfg.Port
 	server := &http.Server{
 		Addr:    port,
@@ -5,8 +5,10 @@ import { api } from '@/utils';
 
 import { queryKeys } from '../queryKeys';
 
-type UpdateDiscInput = Pick<Disc, 'adjustedFlight' | 'colorHex' | 'notes' | 'plastic' | 'weight'> & {
+// Omitting adjustedFlight keeps the current numbers; null resets them to stock.
+type UpdateDiscInput = Pick<Disc, 'colorHex' | 'notes' | 'plastic' | 'weight'> & {
   _id: string;
+  adjustedFlight?: Disc['adjustedFlight'] | null;
 };
 
 interface DeleteDiscInput {
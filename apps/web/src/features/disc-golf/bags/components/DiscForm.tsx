@@ -98,7 +98,10 @@ export const DiscForm = ({ edit = false, onSuccess, onCancel }: DiscFormProps) =
       }
 
       if (hasDefault) {
-        updateDisc({ _id: selectedDisc._id, ...payload }, { onSuccess: () => onSuccess?.() });
+        updateDisc(
+          { _id: selectedDisc._id, ...payload, adjustedFlight: payload.adjustedFlight ?? null },
+          { onSuccess: () => onSuccess?.() },
+        );
       }
     },
   });